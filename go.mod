@@ -3,14 +3,15 @@ module github.com/itsmurugappan/kubernetes-resource-builder
 go 1.15
 
 require (
-	github.com/pkg/errors v0.9.1 // indirect
-	golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d // indirect
+	github.com/robfig/cron/v3 v3.0.1
 	gotest.tools v2.2.0+incompatible
 	k8s.io/api v0.18.8
 	k8s.io/apimachinery v0.18.8
 	k8s.io/client-go v11.0.1-0.20190805182717-6502b5e7b1b5+incompatible
-	knative.dev/pkg v0.0.0-20200922164940-4bf40ad82aab
-	knative.dev/serving v0.18.1
+	knative.dev/eventing v0.19.3
+	knative.dev/pkg v0.0.0-20201103163404-5514ab0c1fdf
+	knative.dev/serving v0.19.0
+	sigs.k8s.io/structured-merge-diff v1.0.1 // indirect
 )
 
 replace (
@@ -0,0 +1,118 @@
+package knative
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"knative.dev/pkg/apis"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"knative.dev/serving/pkg/autoscaler/config/autoscalerconfig"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
+)
+
+//AutoscalingOption sets an autoscaling.knative.dev annotation
+type AutoscalingOption func(map[string]string)
+
+//WithAutoscaling validates the autoscaling annotations together and
+//returns the option to set them on the revision template.
+//Invalid values or combinations, like cpu metric with the kpa class, return an error
+func WithAutoscaling(options ...AutoscalingOption) (KServiceOption, error) {
+	annotations := make(map[string]string)
+	for _, fn := range options {
+		fn(annotations)
+	}
+	if err := validateAutoscaling(annotations); err != nil {
+		return nil, err
+	}
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Template.ObjectMeta.Annotations = transform.GetStringMap(transform.GetKVfromMap(annotations), ksvc.Spec.Template.ObjectMeta.Annotations)
+	}, nil
+}
+
+//WithMinScale - min number of pods, 0 allows scale to zero
+func WithMinScale(min int32) AutoscalingOption {
+	return func(annotations map[string]string) {
+		annotations[autoscaling.MinScaleAnnotationKey] = strconv.Itoa(int(min))
+	}
+}
+
+//WithMaxScale - max number of pods, 0 is unlimited
+func WithMaxScale(max int32) AutoscalingOption {
+	return func(annotations map[string]string) {
+		annotations[autoscaling.MaxScaleAnnotationKey] = strconv.Itoa(int(max))
+	}
+}
+
+//WithInitialScale - number of pods the revision starts with
+func WithInitialScale(initial int32) AutoscalingOption {
+	return func(annotations map[string]string) {
+		annotations[autoscaling.InitialScaleAnnotationKey] = strconv.Itoa(int(initial))
+	}
+}
+
+//WithTarget - target value of the metric per pod
+func WithTarget(target float64) AutoscalingOption {
+	return func(annotations map[string]string) {
+		annotations[autoscaling.TargetAnnotationKey] = strconv.FormatFloat(target, 'f', -1, 64)
+	}
+}
+
+//WithMetric - metric to scale on, autoscaling.Concurrency, autoscaling.RPS or autoscaling.CPU
+func WithMetric(metric string) AutoscalingOption {
+	return func(annotations map[string]string) {
+		if metric != "" {
+			annotations[autoscaling.MetricAnnotationKey] = metric
+		}
+	}
+}
+
+//WithClass - autoscaler class, autoscaling.KPA or autoscaling.HPA
+func WithClass(class string) AutoscalingOption {
+	return func(annotations map[string]string) {
+		if class != "" {
+			annotations[autoscaling.ClassAnnotationKey] = class
+		}
+	}
+}
+
+//WithWindow - stable window over which the metric is averaged
+func WithWindow(window time.Duration) AutoscalingOption {
+	return func(annotations map[string]string) {
+		annotations[autoscaling.WindowAnnotationKey] = window.String()
+	}
+}
+
+//WithPanicThreshold - percentage of the target which triggers panic mode
+func WithPanicThreshold(percent float64) AutoscalingOption {
+	return func(annotations map[string]string) {
+		annotations[autoscaling.PanicThresholdPercentageAnnotationKey] = strconv.FormatFloat(percent, 'f', -1, 64)
+	}
+}
+
+//WithScaleDownDelay - time to wait before scaling down
+func WithScaleDownDelay(delay time.Duration) AutoscalingOption {
+	return func(annotations map[string]string) {
+		annotations[autoscaling.ScaleDownDelayAnnotationKey] = delay.String()
+	}
+}
+
+//validateAutoscaling runs the knative webhook validation against the default
+//autoscaler config, zero initial scale is left to the cluster config
+func validateAutoscaling(annotations map[string]string) error {
+	config := &autoscalerconfig.Config{AllowZeroInitialScale: true}
+	if errs := autoscaling.ValidateAnnotations(context.Background(), config, annotations).Also(validateScaleDownDelay(annotations)); errs != nil {
+		return errs
+	}
+	return nil
+}
+
+//validateScaleDownDelay rejects the delay for hpa, which does its own stabilization
+func validateScaleDownDelay(annotations map[string]string) *apis.FieldError {
+	if _, ok := annotations[autoscaling.ScaleDownDelayAnnotationKey]; ok && annotations[autoscaling.ClassAnnotationKey] == autoscaling.HPA {
+		return apis.ErrInvalidKeyName(autoscaling.ScaleDownDelayAnnotationKey, apis.CurrentField, "only supported by "+autoscaling.KPA)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package knative
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"knative.dev/serving/pkg/apis/autoscaling"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	teststubknative "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/knative"
+)
+
+func TestWithAutoscaling(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		wantKService servingv1.Service
+		wantErr      string
+		inputOptions []AutoscalingOption
+	}{{
+		name: "kpa with all options",
+		wantKService: teststubknative.ConstructExpectedKService(
+			teststubknative.WithAnnotations(map[string]string{
+				"key1":                                            "val1",
+				autoscaling.MinScaleAnnotationKey:                 "1",
+				autoscaling.MaxScaleAnnotationKey:                 "10",
+				autoscaling.InitialScaleAnnotationKey:             "2",
+				autoscaling.TargetAnnotationKey:                   "50",
+				autoscaling.MetricAnnotationKey:                   "rps",
+				autoscaling.ClassAnnotationKey:                    autoscaling.KPA,
+				autoscaling.WindowAnnotationKey:                   "1m30s",
+				autoscaling.PanicThresholdPercentageAnnotationKey: "150.5",
+				autoscaling.ScaleDownDelayAnnotationKey:           "5m0s",
+			})),
+		inputOptions: []AutoscalingOption{
+			WithMinScale(int32(1)),
+			WithMaxScale(int32(10)),
+			WithInitialScale(int32(2)),
+			WithTarget(float64(50)),
+			WithMetric(autoscaling.RPS),
+			WithClass(autoscaling.KPA),
+			WithWindow(90 * time.Second),
+			WithPanicThreshold(150.5),
+			WithScaleDownDelay(5 * time.Minute),
+		},
+	}, {
+		name: "hpa with cpu",
+		wantKService: teststubknative.ConstructExpectedKService(
+			teststubknative.WithAnnotations(map[string]string{
+				"key1":                            "val1",
+				autoscaling.MetricAnnotationKey:   "cpu",
+				autoscaling.ClassAnnotationKey:    autoscaling.HPA,
+				autoscaling.TargetAnnotationKey:   "80",
+				autoscaling.MaxScaleAnnotationKey: "5",
+			})),
+		inputOptions: []AutoscalingOption{
+			WithMetric(autoscaling.CPU),
+			WithClass(autoscaling.HPA),
+			WithTarget(float64(80)),
+			WithMaxScale(int32(5)),
+		},
+	}, {
+		name:         "cpu metric on kpa",
+		wantErr:      autoscaling.MetricAnnotationKey,
+		inputOptions: []AutoscalingOption{WithMetric(autoscaling.CPU)},
+	}, {
+		name:    "max less than min",
+		wantErr: "maxScale=2 is less than minScale=3",
+		inputOptions: []AutoscalingOption{
+			WithMinScale(int32(3)),
+			WithMaxScale(int32(2)),
+		},
+	}, {
+		name:         "window too short",
+		wantErr:      autoscaling.WindowAnnotationKey,
+		inputOptions: []AutoscalingOption{WithWindow(time.Second)},
+	}, {
+		name:    "scale down delay on hpa",
+		wantErr: autoscaling.ScaleDownDelayAnnotationKey,
+		inputOptions: []AutoscalingOption{
+			WithClass(autoscaling.HPA),
+			WithScaleDownDelay(time.Minute),
+		},
+	}, {
+		name:         "scale down delay too long",
+		wantErr:      autoscaling.ScaleDownDelayAnnotationKey,
+		inputOptions: []AutoscalingOption{WithScaleDownDelay(2 * time.Hour)},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			opt, err := WithAutoscaling(tc.inputOptions...)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			actKService := GetKService("foo", WithAnnotations([]kubernetes.KV{{"key1", "val1"}}), opt)
+			assert.DeepEqual(t, &tc.wantKService, &actKService)
+		})
+	}
+}
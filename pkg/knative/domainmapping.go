@@ -0,0 +1,84 @@
+package knative
+
+import (
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	servingv1alpha1 "knative.dev/serving/pkg/apis/serving/v1alpha1"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
+)
+
+type DomainMappingOption func(*servingv1alpha1.DomainMapping)
+
+//GetDomainMapping construct domain mapping spec for the custom domain,
+//the domain is the name of the mapping
+func GetDomainMapping(domain string, options ...DomainMappingOption) servingv1alpha1.DomainMapping {
+	dm := servingv1alpha1.DomainMapping{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DomainMapping",
+			APIVersion: "serving.knative.dev/v1alpha1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: domain,
+		}}
+
+	for _, fn := range options {
+		fn(&dm)
+	}
+	return dm
+}
+
+//WithKServiceRef - knative service the domain is mapped to,
+//the mapping has to be created in the namespace of the service
+func WithKServiceRef(ksvc servingv1.Service) DomainMappingOption {
+	return func(dm *servingv1alpha1.DomainMapping) {
+		dm.Spec.Ref = duckv1.KReference{
+			Kind:       "Service",
+			APIVersion: "serving.knative.dev/v1",
+			Name:       ksvc.Name,
+			Namespace:  ksvc.Namespace,
+		}
+	}
+}
+
+//GetDomainMapping returns the domain mapping for the domain and namespace
+func (c kClient) GetDomainMapping(ns, domain string) (*servingv1alpha1.DomainMapping, error) {
+	return c.tservingv1alpha1.DomainMappings(ns).Get(c.ctx, domain, metav1.GetOptions{})
+}
+
+//CreateDomainMapping creates the domain mapping in the namespace
+func (c kClient) CreateDomainMapping(ns string, dm *servingv1alpha1.DomainMapping) (*servingv1alpha1.DomainMapping, error) {
+	return c.tservingv1alpha1.DomainMappings(ns).Create(c.ctx, dm, metav1.CreateOptions{})
+}
+
+//DeleteDomainMapping deletes the domain mapping for the domain and namespace
+func (c kClient) DeleteDomainMapping(ns, domain string) error {
+	return c.tservingv1alpha1.DomainMappings(ns).Delete(c.ctx, domain, metav1.DeleteOptions{})
+}
+
+//ApplyDomainMapping creates the domain mapping if it is not present otherwise
+//points the existing mapping to the new ref, labels, annotations and owner
+//references are copied on to the existing mapping. update is retried on conflict
+func (c kClient) ApplyDomainMapping(ns string, dm *servingv1alpha1.DomainMapping) (*servingv1alpha1.DomainMapping, error) {
+	var applied *servingv1alpha1.DomainMapping
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.GetDomainMapping(ns, dm.Name)
+		if apierrs.IsNotFound(err) {
+			applied, err = c.CreateDomainMapping(ns, dm)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		desired := existing.DeepCopy()
+		desired.Spec = *dm.Spec.DeepCopy()
+		transform.ApplyMeta(&desired.ObjectMeta, dm.ObjectMeta)
+		applied, err = c.tservingv1alpha1.DomainMappings(ns).Update(c.ctx, desired, metav1.UpdateOptions{})
+		return err
+	})
+	return applied, err
+}
@@ -0,0 +1,51 @@
+package knative
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	servingv1alpha1 "knative.dev/serving/pkg/apis/serving/v1alpha1"
+	fakeserving "knative.dev/serving/pkg/client/clientset/versioned/fake"
+
+	teststubknative "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/knative"
+)
+
+func TestGetDomainMapping(t *testing.T) {
+	want := servingv1alpha1.DomainMapping{
+		TypeMeta:   metav1.TypeMeta{Kind: "DomainMapping", APIVersion: "serving.knative.dev/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "api.example.com"},
+		Spec: servingv1alpha1.DomainMappingSpec{
+			Ref: duckv1.KReference{Kind: "Service", APIVersion: "serving.knative.dev/v1", Name: "foo", Namespace: "ns"},
+		},
+	}
+	act := GetDomainMapping("api.example.com",
+		WithKServiceRef(teststubknative.ConstructExpectedKService(teststubknative.WithNamespace("ns"))))
+	assert.DeepEqual(t, &want, &act)
+}
+
+func TestApplyDomainMapping(t *testing.T) {
+	c := kClient{tservingv1alpha1: fakeserving.NewSimpleClientset().ServingV1alpha1(), ctx: context.Background()}
+	dm := GetDomainMapping("api.example.com",
+		WithKServiceRef(teststubknative.ConstructExpectedKService(teststubknative.WithNamespace("ns"))))
+	_, err := c.ApplyDomainMapping("ns", &dm)
+	assert.NilError(t, err)
+
+	ksvc := teststubknative.ConstructExpectedKService(teststubknative.WithNamespace("ns"))
+	ksvc.Name = "bar"
+	dm = GetDomainMapping("api.example.com", WithKServiceRef(ksvc))
+	dm.OwnerReferences = []metav1.OwnerReference{{APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "1234"}}
+	_, err = c.ApplyDomainMapping("ns", &dm)
+	assert.NilError(t, err)
+
+	act, err := c.GetDomainMapping("ns", "api.example.com")
+	assert.NilError(t, err)
+	assert.Equal(t, "bar", act.Spec.Ref.Name)
+	assert.DeepEqual(t, dm.OwnerReferences, act.OwnerReferences)
+
+	assert.NilError(t, c.DeleteDomainMapping("ns", "api.example.com"))
+}
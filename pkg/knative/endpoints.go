@@ -0,0 +1,66 @@
+package knative
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/apis"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+const (
+	//ENDPOINTS_NOT_READY - error message to indicate the url is not resolved yet
+	ENDPOINTS_NOT_READY = "%s %s is not ready, url is not resolved yet"
+)
+
+//Endpoints - resolved urls of a ready knative service or route
+type Endpoints struct {
+	//URL is the external url splitting traffic over all targets
+	URL *apis.URL
+	//Address is the cluster local url
+	Address *apis.URL
+	//Tags holds the url of each tagged traffic target
+	Tags map[string]*apis.URL
+}
+
+//GetKServiceEndpoints returns the urls of the knative service once it is ready
+func (c kClient) GetKServiceEndpoints(ns, name string) (*Endpoints, error) {
+	ksvc, err := c.GetKService(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ksvc.IsReady() {
+		return nil, fmt.Errorf(ENDPOINTS_NOT_READY, "ksvc", name)
+	}
+	return endpointsFromStatus(ksvc.Status.RouteStatusFields), nil
+}
+
+//GetRouteEndpoints returns the urls of the route once it is ready
+func (c kClient) GetRouteEndpoints(ns, name string) (*Endpoints, error) {
+	route, err := c.tservingv1.Routes(ns).Get(c.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !route.IsReady() {
+		return nil, fmt.Errorf(ENDPOINTS_NOT_READY, "route", name)
+	}
+	return endpointsFromStatus(route.Status.RouteStatusFields), nil
+}
+
+func endpointsFromStatus(status servingv1.RouteStatusFields) *Endpoints {
+	endpoints := &Endpoints{URL: status.URL}
+	if status.Address != nil {
+		endpoints.Address = status.Address.URL
+	}
+	for _, target := range status.Traffic {
+		if target.Tag == "" || target.URL == nil {
+			continue
+		}
+		if endpoints.Tags == nil {
+			endpoints.Tags = make(map[string]*apis.URL)
+		}
+		endpoints.Tags[target.Tag] = target.URL
+	}
+	return endpoints
+}
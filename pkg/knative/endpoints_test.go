@@ -0,0 +1,63 @@
+package knative
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gotest.tools/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"knative.dev/pkg/apis"
+	fakeserving "knative.dev/serving/pkg/client/clientset/versioned/fake"
+
+	teststubknative "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/knative"
+)
+
+func TestGetKServiceEndpoints(t *testing.T) {
+	mustParse := func(u string) *apis.URL {
+		url, err := apis.ParseURL(u)
+		assert.NilError(t, err)
+		return url
+	}
+	for _, tc := range []struct {
+		name    string
+		ready   corev1.ConditionStatus
+		want    *Endpoints
+		wantErr string
+	}{{
+		name:  "ready service",
+		ready: corev1.ConditionTrue,
+		want: &Endpoints{
+			URL:     mustParse("http://foo.ns.example.com"),
+			Address: mustParse("http://foo.ns.svc.cluster.local"),
+			Tags: map[string]*apis.URL{
+				"canary": mustParse("http://canary-foo.ns.example.com"),
+			},
+		},
+	}, {
+		name:    "service not ready",
+		ready:   corev1.ConditionUnknown,
+		wantErr: fmt.Sprintf(ENDPOINTS_NOT_READY, "ksvc", "foo"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := fakeserving.NewSimpleClientset(ksvcPtr(teststubknative.ConstructExpectedKService(
+				teststubknative.WithNamespace("ns"),
+				teststubknative.WithCondition(apis.ConditionReady, tc.ready, "", ""),
+				teststubknative.WithURL("http://foo.ns.example.com"),
+				teststubknative.WithAddress("http://foo.ns.svc.cluster.local"),
+				teststubknative.WithStatusTraffic("", "foo-2", int64(100)),
+				teststubknative.WithTagURL("canary", "foo-3", "http://canary-foo.ns.example.com"),
+			)))
+			c := kClient{tservingv1: cs.ServingV1(), ctx: context.Background()}
+			act, err := c.GetKServiceEndpoints("ns", "foo")
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+			} else {
+				assert.NilError(t, err)
+				assert.DeepEqual(t, tc.want, act)
+			}
+		})
+	}
+}
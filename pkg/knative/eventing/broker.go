@@ -0,0 +1,102 @@
+package eventing
+
+import (
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	"knative.dev/eventing/pkg/apis/eventing"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
+)
+
+type BrokerOption func(*eventingv1.Broker)
+
+//GetBroker construct broker spec based on option provided
+func GetBroker(name string, options ...BrokerOption) eventingv1.Broker {
+	broker := eventingv1.Broker{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Broker",
+			APIVersion: "eventing.knative.dev/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&broker)
+	}
+	return broker
+}
+
+//WithBrokerClass - broker implementation, eventing.MTChannelBrokerClassValue for the channel based broker
+func WithBrokerClass(class string) BrokerOption {
+	return func(broker *eventingv1.Broker) {
+		if class != "" {
+			broker.ObjectMeta.Annotations = transform.GetStringMap([]kubernetes.KV{{Key: eventing.BrokerClassKey, Value: class}}, broker.ObjectMeta.Annotations)
+		}
+	}
+}
+
+//WithBrokerConfig - reference to the broker class specific configuration
+func WithBrokerConfig(config duckv1.KReference) BrokerOption {
+	return func(broker *eventingv1.Broker) {
+		if config.Name != "" {
+			broker.Spec.Config = &config
+		}
+	}
+}
+
+//WithBrokerDelivery - dead letter sink and number of retries for undelivered events
+func WithBrokerDelivery(deadLetterSink duckv1.Destination, retry int32) BrokerOption {
+	return func(broker *eventingv1.Broker) {
+		delivery := &eventingduckv1.DeliverySpec{}
+		if deadLetterSink.Ref != nil || deadLetterSink.URI != nil {
+			delivery.DeadLetterSink = &deadLetterSink
+		}
+		if retry > int32(0) {
+			delivery.Retry = ptr.Int32(retry)
+		}
+		if delivery.DeadLetterSink != nil || delivery.Retry != nil {
+			broker.Spec.Delivery = delivery
+		}
+	}
+}
+
+//GetBroker returns the broker for the name and namespace
+func (c *eventingClient) GetBroker(ns, name string) (*eventingv1.Broker, error) {
+	return c.teventingv1.Brokers(ns).Get(c.ctx, name, metav1.GetOptions{})
+}
+
+//CreateBroker creates the broker in the namespace
+func (c *eventingClient) CreateBroker(ns string, broker *eventingv1.Broker) (*eventingv1.Broker, error) {
+	return c.teventingv1.Brokers(ns).Create(c.ctx, broker, metav1.CreateOptions{})
+}
+
+//ApplyBroker creates the broker if it is not present otherwise updates
+//the spec, labels and annotations of the existing broker. update is retried on conflict
+func (c *eventingClient) ApplyBroker(ns string, broker *eventingv1.Broker) (*eventingv1.Broker, error) {
+	var applied *eventingv1.Broker
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.GetBroker(ns, broker.Name)
+		if apierrs.IsNotFound(err) {
+			applied, err = c.CreateBroker(ns, broker)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		desired := existing.DeepCopy()
+		desired.Spec = *broker.Spec.DeepCopy()
+		transform.ApplyMeta(&desired.ObjectMeta, broker.ObjectMeta)
+		applied, err = c.teventingv1.Brokers(ns).Update(c.ctx, desired, metav1.UpdateOptions{})
+		return err
+	})
+	return applied, err
+}
+
@@ -0,0 +1,97 @@
+package eventing
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	eventingduckv1 "knative.dev/eventing/pkg/apis/duck/v1"
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	fakeeventing "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+)
+
+func TestGetBroker(t *testing.T) {
+	dls, err := URIDestination("http://dls.ns.svc.cluster.local")
+	assert.NilError(t, err)
+	for _, tc := range []struct {
+		name         string
+		want         eventingv1.Broker
+		inputOptions []BrokerOption
+	}{{
+		name: "broker with all options",
+		want: eventingv1.Broker{
+			TypeMeta: metav1.TypeMeta{Kind: "Broker", APIVersion: "eventing.knative.dev/v1"},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "default",
+				Annotations: map[string]string{"eventing.knative.dev/broker.class": "MTChannelBasedBroker"},
+			},
+			Spec: eventingv1.BrokerSpec{
+				Config: &duckv1.KReference{Kind: "ConfigMap", APIVersion: "v1", Name: "config-br-defaults", Namespace: "knative-eventing"},
+				Delivery: &eventingduckv1.DeliverySpec{
+					DeadLetterSink: &dls,
+					Retry:          ptr.Int32(3),
+				},
+			},
+		},
+		inputOptions: []BrokerOption{
+			WithBrokerClass("MTChannelBasedBroker"),
+			WithBrokerConfig(duckv1.KReference{Kind: "ConfigMap", APIVersion: "v1", Name: "config-br-defaults", Namespace: "knative-eventing"}),
+			WithBrokerDelivery(dls, int32(3)),
+		},
+	}, {
+		name: "broker with null options",
+		want: eventingv1.Broker{
+			TypeMeta:   metav1.TypeMeta{Kind: "Broker", APIVersion: "eventing.knative.dev/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		},
+		inputOptions: []BrokerOption{
+			WithBrokerClass(""),
+			WithBrokerConfig(duckv1.KReference{}),
+			WithBrokerDelivery(duckv1.Destination{}, int32(0)),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			act := GetBroker("default", tc.inputOptions...)
+			assert.DeepEqual(t, &tc.want, &act)
+		})
+	}
+}
+
+func TestApplyBroker(t *testing.T) {
+	existing := GetBroker("default", WithBrokerDelivery(duckv1.Destination{}, int32(1)))
+	existing.Namespace = "ns"
+	existing.ResourceVersion = "5"
+	existing.Labels = map[string]string{"k1": "v1"}
+	for _, tc := range []struct {
+		name           string
+		runtimeObjects []runtime.Object
+		wantVersion    string
+		wantLabels     map[string]string
+	}{{
+		name:       "broker created",
+		wantLabels: map[string]string{"k2": "v2"},
+	}, {
+		name:           "broker updated",
+		runtimeObjects: []runtime.Object{&existing},
+		wantVersion:    "5",
+		wantLabels:     map[string]string{"k1": "v1", "k2": "v2"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &eventingClient{teventingv1: fakeeventing.NewSimpleClientset(tc.runtimeObjects...).EventingV1(), ctx: context.Background()}
+			broker := GetBroker("default", WithBrokerDelivery(duckv1.Destination{}, int32(5)))
+			broker.Namespace = "ns"
+			broker.Labels = map[string]string{"k2": "v2"}
+			act, err := c.ApplyBroker("ns", &broker)
+			assert.NilError(t, err)
+			assert.Equal(t, tc.wantVersion, act.ResourceVersion)
+			assert.DeepEqual(t, tc.wantLabels, act.Labels)
+			assert.DeepEqual(t, broker.Spec, act.Spec)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package eventing
+
+import (
+	"context"
+
+	"knative.dev/eventing/pkg/client/clientset/versioned"
+	typedeventingv1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/eventing/v1"
+	typedsourcesv1beta1 "knative.dev/eventing/pkg/client/clientset/versioned/typed/sources/v1beta1"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+)
+
+type eventingClient struct {
+	teventingv1     typedeventingv1.EventingV1Interface
+	tsourcesv1beta1 typedsourcesv1beta1.SourcesV1beta1Interface
+	ctx             context.Context
+}
+
+func Client(c context.Context) *eventingClient {
+	cfg := kubernetes.CfgFromContext(c)
+	ecs, _ := versioned.NewForConfig(cfg)
+
+	return &eventingClient{
+		teventingv1:     ecs.EventingV1(),
+		tsourcesv1beta1: ecs.SourcesV1beta1(),
+		ctx:             c,
+	}
+}
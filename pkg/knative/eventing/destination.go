@@ -0,0 +1,43 @@
+package eventing
+
+import (
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+)
+
+//KServiceDestination - destination referring the knative service,
+//works with the services built by knative.GetKService
+func KServiceDestination(ksvc servingv1.Service) duckv1.Destination {
+	return duckv1.Destination{
+		Ref: &duckv1.KReference{
+			Kind:       "Service",
+			APIVersion: "serving.knative.dev/v1",
+			Name:       ksvc.Name,
+			Namespace:  ksvc.Namespace,
+		},
+	}
+}
+
+//BrokerDestination - destination referring the broker
+func BrokerDestination(broker eventingv1.Broker) duckv1.Destination {
+	return duckv1.Destination{
+		Ref: &duckv1.KReference{
+			Kind:       "Broker",
+			APIVersion: "eventing.knative.dev/v1",
+			Name:       broker.Name,
+			Namespace:  broker.Namespace,
+		},
+	}
+}
+
+//URIDestination - destination for a plain url
+func URIDestination(uri string) (duckv1.Destination, error) {
+	url, err := apis.ParseURL(uri)
+	if err != nil {
+		return duckv1.Destination{}, err
+	}
+	return duckv1.Destination{URI: url}, nil
+}
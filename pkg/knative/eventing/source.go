@@ -0,0 +1,257 @@
+package eventing
+
+import (
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	sourcesv1beta1 "knative.dev/eventing/pkg/apis/sources/v1beta1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/tracker"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
+)
+
+type PingSourceOption func(*sourcesv1beta1.PingSource)
+
+type ApiServerSourceOption func(*sourcesv1beta1.ApiServerSource)
+
+type SinkBindingOption func(*sourcesv1beta1.SinkBinding)
+
+//GetPingSource construct ping source spec based on option provided
+func GetPingSource(name string, options ...PingSourceOption) sourcesv1beta1.PingSource {
+	source := sourcesv1beta1.PingSource{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PingSource",
+			APIVersion: "sources.knative.dev/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&source)
+	}
+	return source
+}
+
+//WithSchedule - cron schedule the events are sent on
+func WithSchedule(schedule, timezone string) PingSourceOption {
+	return func(source *sourcesv1beta1.PingSource) {
+		if schedule != "" {
+			source.Spec.Schedule = schedule
+			source.Spec.Timezone = timezone
+		}
+	}
+}
+
+//WithJSONData - json payload of the events
+func WithJSONData(data string) PingSourceOption {
+	return func(source *sourcesv1beta1.PingSource) {
+		if data != "" {
+			source.Spec.JsonData = data
+		}
+	}
+}
+
+//WithPingSink - destination of the ping events
+func WithPingSink(sink duckv1.Destination) PingSourceOption {
+	return func(source *sourcesv1beta1.PingSource) {
+		source.Spec.Sink = sink
+	}
+}
+
+//GetApiServerSource construct api server source spec based on option provided
+func GetApiServerSource(name string, options ...ApiServerSourceOption) sourcesv1beta1.ApiServerSource {
+	source := sourcesv1beta1.ApiServerSource{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ApiServerSource",
+			APIVersion: "sources.knative.dev/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&source)
+	}
+	return source
+}
+
+//WithResource - kind of the kubernetes resources to watch, selector is optional
+func WithResource(apiVersion, kind string, selector []kubernetes.KV) ApiServerSourceOption {
+	return func(source *sourcesv1beta1.ApiServerSource) {
+		if kind != "" {
+			resource := sourcesv1beta1.APIVersionKindSelector{
+				APIVersion: apiVersion,
+				Kind:       kind,
+			}
+			if matchLabels := transform.GetStringMap(selector, nil); matchLabels != nil {
+				resource.LabelSelector = &metav1.LabelSelector{MatchLabels: matchLabels}
+			}
+			source.Spec.Resources = append(source.Spec.Resources, resource)
+		}
+	}
+}
+
+//WithEventMode - sourcesv1beta1.ReferenceMode or sourcesv1beta1.ResourceMode
+func WithEventMode(mode string) ApiServerSourceOption {
+	return func(source *sourcesv1beta1.ApiServerSource) {
+		if mode != "" {
+			source.Spec.EventMode = mode
+		}
+	}
+}
+
+//WithApiServerServiceAccount - service account with access to the watched resources
+func WithApiServerServiceAccount(sa string) ApiServerSourceOption {
+	return func(source *sourcesv1beta1.ApiServerSource) {
+		if sa != "" {
+			source.Spec.ServiceAccountName = sa
+		}
+	}
+}
+
+//WithApiServerSink - destination of the api server events
+func WithApiServerSink(sink duckv1.Destination) ApiServerSourceOption {
+	return func(source *sourcesv1beta1.ApiServerSource) {
+		source.Spec.Sink = sink
+	}
+}
+
+//GetSinkBinding construct sink binding spec based on option provided
+func GetSinkBinding(name string, options ...SinkBindingOption) sourcesv1beta1.SinkBinding {
+	binding := sourcesv1beta1.SinkBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "SinkBinding",
+			APIVersion: "sources.knative.dev/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&binding)
+	}
+	return binding
+}
+
+//WithSubject - resource the sink is injected into, referred by name or by selector
+func WithSubject(apiVersion, kind, name string, selector []kubernetes.KV) SinkBindingOption {
+	return func(binding *sourcesv1beta1.SinkBinding) {
+		subject := tracker.Reference{
+			APIVersion: apiVersion,
+			Kind:       kind,
+			Name:       name,
+		}
+		if matchLabels := transform.GetStringMap(selector, nil); matchLabels != nil {
+			subject.Name = ""
+			subject.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+		}
+		binding.Spec.Subject = subject
+	}
+}
+
+//WithBindingSink - destination injected as K_SINK into the subject
+func WithBindingSink(sink duckv1.Destination) SinkBindingOption {
+	return func(binding *sourcesv1beta1.SinkBinding) {
+		binding.Spec.Sink = sink
+	}
+}
+
+//GetPingSource returns the ping source for the name and namespace
+func (c *eventingClient) GetPingSource(ns, name string) (*sourcesv1beta1.PingSource, error) {
+	return c.tsourcesv1beta1.PingSources(ns).Get(c.ctx, name, metav1.GetOptions{})
+}
+
+//CreatePingSource creates the ping source in the namespace
+func (c *eventingClient) CreatePingSource(ns string, source *sourcesv1beta1.PingSource) (*sourcesv1beta1.PingSource, error) {
+	return c.tsourcesv1beta1.PingSources(ns).Create(c.ctx, source, metav1.CreateOptions{})
+}
+
+//ApplyPingSource creates the ping source if it is not present otherwise updates
+//the spec, labels and annotations of the existing source. update is retried on conflict
+func (c *eventingClient) ApplyPingSource(ns string, source *sourcesv1beta1.PingSource) (*sourcesv1beta1.PingSource, error) {
+	var applied *sourcesv1beta1.PingSource
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.GetPingSource(ns, source.Name)
+		if apierrs.IsNotFound(err) {
+			applied, err = c.CreatePingSource(ns, source)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		desired := existing.DeepCopy()
+		desired.Spec = *source.Spec.DeepCopy()
+		transform.ApplyMeta(&desired.ObjectMeta, source.ObjectMeta)
+		applied, err = c.tsourcesv1beta1.PingSources(ns).Update(c.ctx, desired, metav1.UpdateOptions{})
+		return err
+	})
+	return applied, err
+}
+
+//GetApiServerSource returns the api server source for the name and namespace
+func (c *eventingClient) GetApiServerSource(ns, name string) (*sourcesv1beta1.ApiServerSource, error) {
+	return c.tsourcesv1beta1.ApiServerSources(ns).Get(c.ctx, name, metav1.GetOptions{})
+}
+
+//CreateApiServerSource creates the api server source in the namespace
+func (c *eventingClient) CreateApiServerSource(ns string, source *sourcesv1beta1.ApiServerSource) (*sourcesv1beta1.ApiServerSource, error) {
+	return c.tsourcesv1beta1.ApiServerSources(ns).Create(c.ctx, source, metav1.CreateOptions{})
+}
+
+//ApplyApiServerSource creates the api server source if it is not present otherwise updates
+//the spec, labels and annotations of the existing source. update is retried on conflict
+func (c *eventingClient) ApplyApiServerSource(ns string, source *sourcesv1beta1.ApiServerSource) (*sourcesv1beta1.ApiServerSource, error) {
+	var applied *sourcesv1beta1.ApiServerSource
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.GetApiServerSource(ns, source.Name)
+		if apierrs.IsNotFound(err) {
+			applied, err = c.CreateApiServerSource(ns, source)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		desired := existing.DeepCopy()
+		desired.Spec = *source.Spec.DeepCopy()
+		transform.ApplyMeta(&desired.ObjectMeta, source.ObjectMeta)
+		applied, err = c.tsourcesv1beta1.ApiServerSources(ns).Update(c.ctx, desired, metav1.UpdateOptions{})
+		return err
+	})
+	return applied, err
+}
+
+//GetSinkBinding returns the sink binding for the name and namespace
+func (c *eventingClient) GetSinkBinding(ns, name string) (*sourcesv1beta1.SinkBinding, error) {
+	return c.tsourcesv1beta1.SinkBindings(ns).Get(c.ctx, name, metav1.GetOptions{})
+}
+
+//CreateSinkBinding creates the sink binding in the namespace
+func (c *eventingClient) CreateSinkBinding(ns string, binding *sourcesv1beta1.SinkBinding) (*sourcesv1beta1.SinkBinding, error) {
+	return c.tsourcesv1beta1.SinkBindings(ns).Create(c.ctx, binding, metav1.CreateOptions{})
+}
+
+//ApplySinkBinding creates the sink binding if it is not present otherwise updates
+//the spec, labels and annotations of the existing binding. update is retried on conflict
+func (c *eventingClient) ApplySinkBinding(ns string, binding *sourcesv1beta1.SinkBinding) (*sourcesv1beta1.SinkBinding, error) {
+	var applied *sourcesv1beta1.SinkBinding
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.GetSinkBinding(ns, binding.Name)
+		if apierrs.IsNotFound(err) {
+			applied, err = c.CreateSinkBinding(ns, binding)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		desired := existing.DeepCopy()
+		desired.Spec = *binding.Spec.DeepCopy()
+		transform.ApplyMeta(&desired.ObjectMeta, binding.ObjectMeta)
+		applied, err = c.tsourcesv1beta1.SinkBindings(ns).Update(c.ctx, desired, metav1.UpdateOptions{})
+		return err
+	})
+	return applied, err
+}
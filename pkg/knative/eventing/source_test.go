@@ -0,0 +1,114 @@
+package eventing
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	sourcesv1beta1 "knative.dev/eventing/pkg/apis/sources/v1beta1"
+	fakeeventing "knative.dev/eventing/pkg/client/clientset/versioned/fake"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	"knative.dev/pkg/tracker"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+)
+
+func TestGetPingSource(t *testing.T) {
+	broker := GetBroker("default")
+	want := sourcesv1beta1.PingSource{
+		TypeMeta:   metav1.TypeMeta{Kind: "PingSource", APIVersion: "sources.knative.dev/v1beta1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: sourcesv1beta1.PingSourceSpec{
+			SourceSpec: duckv1.SourceSpec{Sink: duckv1.Destination{
+				Ref: &duckv1.KReference{Kind: "Broker", APIVersion: "eventing.knative.dev/v1", Name: "default"},
+			}},
+			Schedule: "*/5 * * * *",
+			Timezone: "Europe/Paris",
+			JsonData: `{"msg":"hi"}`,
+		},
+	}
+	act := GetPingSource("foo",
+		WithSchedule("*/5 * * * *", "Europe/Paris"),
+		WithJSONData(`{"msg":"hi"}`),
+		WithPingSink(BrokerDestination(broker)))
+	assert.DeepEqual(t, &want, &act)
+}
+
+func TestGetApiServerSource(t *testing.T) {
+	sink, err := URIDestination("http://events.ns.svc.cluster.local")
+	assert.NilError(t, err)
+	want := sourcesv1beta1.ApiServerSource{
+		TypeMeta:   metav1.TypeMeta{Kind: "ApiServerSource", APIVersion: "sources.knative.dev/v1beta1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		Spec: sourcesv1beta1.ApiServerSourceSpec{
+			SourceSpec: duckv1.SourceSpec{Sink: sink},
+			Resources: []sourcesv1beta1.APIVersionKindSelector{
+				{APIVersion: "v1", Kind: "Event"},
+				{APIVersion: "batch/v1", Kind: "Job", LabelSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "etl"}}},
+			},
+			EventMode:          sourcesv1beta1.ResourceMode,
+			ServiceAccountName: "events-sa",
+		},
+	}
+	act := GetApiServerSource("foo",
+		WithResource("v1", "Event", nil),
+		WithResource("batch/v1", "Job", []kubernetes.KV{{Key: "app", Value: "etl"}}),
+		WithResource("v1", "", nil),
+		WithEventMode(sourcesv1beta1.ResourceMode),
+		WithApiServerServiceAccount("events-sa"),
+		WithApiServerSink(sink))
+	assert.DeepEqual(t, &want, &act)
+}
+
+func TestGetSinkBinding(t *testing.T) {
+	broker := GetBroker("default")
+	for _, tc := range []struct {
+		name         string
+		want         tracker.Reference
+		inputOptions []SinkBindingOption
+	}{{
+		name: "subject by name",
+		want: tracker.Reference{APIVersion: "apps/v1", Kind: "Deployment", Name: "producer"},
+		inputOptions: []SinkBindingOption{
+			WithSubject("apps/v1", "Deployment", "producer", nil),
+		},
+	}, {
+		name: "subject by selector",
+		want: tracker.Reference{APIVersion: "batch/v1", Kind: "Job", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "etl"}}},
+		inputOptions: []SinkBindingOption{
+			WithSubject("batch/v1", "Job", "ignored", []kubernetes.KV{{Key: "app", Value: "etl"}}),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			want := sourcesv1beta1.SinkBinding{
+				TypeMeta:   metav1.TypeMeta{Kind: "SinkBinding", APIVersion: "sources.knative.dev/v1beta1"},
+				ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+				Spec: sourcesv1beta1.SinkBindingSpec{
+					SourceSpec:  duckv1.SourceSpec{Sink: BrokerDestination(broker)},
+					BindingSpec: duckv1beta1.BindingSpec{Subject: tc.want},
+				},
+			}
+			act := GetSinkBinding("foo", append(tc.inputOptions, WithBindingSink(BrokerDestination(broker)))...)
+			assert.DeepEqual(t, &want, &act)
+		})
+	}
+}
+
+func TestApplyPingSource(t *testing.T) {
+	c := &eventingClient{tsourcesv1beta1: fakeeventing.NewSimpleClientset().SourcesV1beta1(), ctx: context.Background()}
+	source := GetPingSource("foo", WithSchedule("* * * * *", ""))
+	_, err := c.ApplyPingSource("ns", &source)
+	assert.NilError(t, err)
+
+	source = GetPingSource("foo", WithSchedule("0 * * * *", ""))
+	_, err = c.ApplyPingSource("ns", &source)
+	assert.NilError(t, err)
+
+	act, err := c.GetPingSource("ns", "foo")
+	assert.NilError(t, err)
+	assert.Equal(t, "0 * * * *", act.Spec.Schedule)
+}
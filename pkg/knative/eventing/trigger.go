@@ -0,0 +1,93 @@
+package eventing
+
+import (
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
+)
+
+type TriggerOption func(*eventingv1.Trigger)
+
+//GetTrigger construct trigger spec based on option provided
+func GetTrigger(name string, options ...TriggerOption) eventingv1.Trigger {
+	trigger := eventingv1.Trigger{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Trigger",
+			APIVersion: "eventing.knative.dev/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&trigger)
+	}
+	return trigger
+}
+
+//WithBroker - broker the trigger subscribes to
+func WithBroker(broker string) TriggerOption {
+	return func(trigger *eventingv1.Trigger) {
+		if broker != "" {
+			trigger.Spec.Broker = broker
+		}
+	}
+}
+
+//WithFilter - exact match on cloud event attributes like type and source
+func WithFilter(attributes []kubernetes.KV) TriggerOption {
+	return func(trigger *eventingv1.Trigger) {
+		if len(attributes) > 0 && attributes[0].Key != "" {
+			if trigger.Spec.Filter == nil {
+				trigger.Spec.Filter = &eventingv1.TriggerFilter{}
+			}
+			trigger.Spec.Filter.Attributes = transform.GetStringMap(attributes, trigger.Spec.Filter.Attributes)
+		}
+	}
+}
+
+//WithSubscriber - destination the filtered events are delivered to,
+//use KServiceDestination to deliver to a knative service
+func WithSubscriber(subscriber duckv1.Destination) TriggerOption {
+	return func(trigger *eventingv1.Trigger) {
+		trigger.Spec.Subscriber = subscriber
+	}
+}
+
+//GetTrigger returns the trigger for the name and namespace
+func (c *eventingClient) GetTrigger(ns, name string) (*eventingv1.Trigger, error) {
+	return c.teventingv1.Triggers(ns).Get(c.ctx, name, metav1.GetOptions{})
+}
+
+//CreateTrigger creates the trigger in the namespace
+func (c *eventingClient) CreateTrigger(ns string, trigger *eventingv1.Trigger) (*eventingv1.Trigger, error) {
+	return c.teventingv1.Triggers(ns).Create(c.ctx, trigger, metav1.CreateOptions{})
+}
+
+//ApplyTrigger creates the trigger if it is not present otherwise updates
+//the spec, labels and annotations of the existing trigger. update is retried on conflict
+func (c *eventingClient) ApplyTrigger(ns string, trigger *eventingv1.Trigger) (*eventingv1.Trigger, error) {
+	var applied *eventingv1.Trigger
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.GetTrigger(ns, trigger.Name)
+		if apierrs.IsNotFound(err) {
+			applied, err = c.CreateTrigger(ns, trigger)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		desired := existing.DeepCopy()
+		desired.Spec = *trigger.Spec.DeepCopy()
+		transform.ApplyMeta(&desired.ObjectMeta, trigger.ObjectMeta)
+		applied, err = c.teventingv1.Triggers(ns).Update(c.ctx, desired, metav1.UpdateOptions{})
+		return err
+	})
+	return applied, err
+}
@@ -0,0 +1,61 @@
+package eventing
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	eventingv1 "knative.dev/eventing/pkg/apis/eventing/v1"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/knative"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+)
+
+func TestGetTrigger(t *testing.T) {
+	ksvc := knative.GetKService("fn")
+	ksvc.Namespace = "ns"
+	for _, tc := range []struct {
+		name         string
+		want         eventingv1.Trigger
+		inputOptions []TriggerOption
+	}{{
+		name: "trigger to ksvc with filter",
+		want: eventingv1.Trigger{
+			TypeMeta:   metav1.TypeMeta{Kind: "Trigger", APIVersion: "eventing.knative.dev/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+			Spec: eventingv1.TriggerSpec{
+				Broker: "default",
+				Filter: &eventingv1.TriggerFilter{
+					Attributes: eventingv1.TriggerFilterAttributes{"type": "dev.example.order", "source": "shop"},
+				},
+				Subscriber: duckv1.Destination{
+					Ref: &duckv1.KReference{Kind: "Service", APIVersion: "serving.knative.dev/v1", Name: "fn", Namespace: "ns"},
+				},
+			},
+		},
+		inputOptions: []TriggerOption{
+			WithBroker("default"),
+			WithFilter([]kubernetes.KV{{Key: "type", Value: "dev.example.order"}}),
+			WithFilter([]kubernetes.KV{{Key: "source", Value: "shop"}}),
+			WithSubscriber(KServiceDestination(ksvc)),
+		},
+	}, {
+		name: "trigger with null options",
+		want: eventingv1.Trigger{
+			TypeMeta:   metav1.TypeMeta{Kind: "Trigger", APIVersion: "eventing.knative.dev/v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+		},
+		inputOptions: []TriggerOption{
+			WithBroker(""),
+			WithFilter([]kubernetes.KV{{}}),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			act := GetTrigger("foo", tc.inputOptions...)
+			assert.DeepEqual(t, &tc.want, &act)
+		})
+	}
+}
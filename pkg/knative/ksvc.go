@@ -2,18 +2,29 @@ package knative
 
 import (
 	"context"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
 	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	"knative.dev/serving/pkg/client/clientset/versioned"
 	typedservingv1 "knative.dev/serving/pkg/client/clientset/versioned/typed/serving/v1"
+	typedservingv1alpha1 "knative.dev/serving/pkg/client/clientset/versioned/typed/serving/v1alpha1"
 
 	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/corev1"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
 )
 
+type KServiceOption func(*servingv1.Service)
+
 type kClient struct {
-	tservingv1 typedservingv1.ServingV1Interface
-	ctx        context.Context
+	tservingv1       typedservingv1.ServingV1Interface
+	tservingv1alpha1 typedservingv1alpha1.ServingV1alpha1Interface
+	ctx              context.Context
 }
 
 func Client(c context.Context) *kClient {
@@ -21,8 +32,9 @@ func Client(c context.Context) *kClient {
 	kcs, _ := versioned.NewForConfig(cfg)
 
 	return &kClient{
-		tservingv1: kcs.ServingV1(),
-		ctx:        c,
+		tservingv1:       kcs.ServingV1(),
+		tservingv1alpha1: kcs.ServingV1alpha1(),
+		ctx:              c,
 	}
 }
 
@@ -30,3 +42,106 @@ func Client(c context.Context) *kClient {
 func (c kClient) GetKService(ns, name string) (*servingv1.Service, error) {
 	return c.tservingv1.Services(ns).Get(c.ctx, name, metav1.GetOptions{})
 }
+
+//CreateKService creates the knative service in the namespace
+func (c kClient) CreateKService(ns string, ksvc *servingv1.Service) (*servingv1.Service, error) {
+	return c.tservingv1.Services(ns).Create(c.ctx, ksvc, metav1.CreateOptions{})
+}
+
+//UpdateKService updates the knative service, ksvc should carry the current resourceVersion
+func (c kClient) UpdateKService(ns string, ksvc *servingv1.Service) (*servingv1.Service, error) {
+	return c.tservingv1.Services(ns).Update(c.ctx, ksvc, metav1.UpdateOptions{})
+}
+
+//DeleteKService deletes the knative service for the name and namespace
+func (c kClient) DeleteKService(ns, name string) error {
+	return c.tservingv1.Services(ns).Delete(c.ctx, name, metav1.DeleteOptions{})
+}
+
+//ApplyKService creates the knative service if it is not present
+//otherwise the spec, labels and annotations are copied on to the existing service
+//so status, resourceVersion and other server set fields are preserved.
+//update is retried on conflict
+func (c kClient) ApplyKService(ns string, ksvc *servingv1.Service) (*servingv1.Service, error) {
+	var applied *servingv1.Service
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.GetKService(ns, ksvc.Name)
+		if apierrs.IsNotFound(err) {
+			applied, err = c.CreateKService(ns, ksvc)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		desired := existing.DeepCopy()
+		desired.Spec = *ksvc.Spec.DeepCopy()
+		transform.ApplyMeta(&desired.ObjectMeta, ksvc.ObjectMeta)
+		applied, err = c.UpdateKService(ns, desired)
+		return err
+	})
+	return applied, err
+}
+
+//GetKService construct knative service spec based on option provided
+func GetKService(name string, options ...KServiceOption) servingv1.Service {
+	ksvc := servingv1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "serving.knative.dev/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&ksvc)
+	}
+	return ksvc
+}
+
+//WithPodSpecOptions - pod spec of the revision template
+func WithPodSpecOptions(podSpec kubernetes.PodSpec, options ...corev1.PodSpecOption) KServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Template.Spec.PodSpec = corev1.GetPodSpec(podSpec, options...)
+	}
+}
+
+//WithAnnotations - annotations of the revision template
+func WithAnnotations(inAnnotations []kubernetes.KV) KServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Template.ObjectMeta.Annotations = transform.GetStringMap(inAnnotations, ksvc.Spec.Template.ObjectMeta.Annotations)
+	}
+}
+
+//WithLabels - labels of the revision template
+func WithLabels(inLabels []kubernetes.KV) KServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Template.ObjectMeta.Labels = transform.GetStringMap(inLabels, ksvc.Spec.Template.ObjectMeta.Labels)
+	}
+}
+
+//WithOwnerReference - sets the controller reference of the service
+func WithOwnerReference(obj kmeta.OwnerRefable) KServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ownerRef := metav1.NewControllerRef(obj.GetObjectMeta(), obj.GetGroupVersionKind())
+		ksvc.ObjectMeta.OwnerReferences = []metav1.OwnerReference{*ownerRef}
+	}
+}
+
+//WithContainerConcurrency - max in-flight requests per container, 0 is unlimited
+func WithContainerConcurrency(concurrency int64) KServiceOption {
+	return func(ksvc *servingv1.Service) {
+		if concurrency >= int64(0) {
+			ksvc.Spec.Template.Spec.ContainerConcurrency = ptr.Int64(concurrency)
+		}
+	}
+}
+
+//WithTimeout - max duration in seconds a request is allowed to take
+func WithTimeout(timeout int64) KServiceOption {
+	return func(ksvc *servingv1.Service) {
+		if timeout > int64(0) {
+			ksvc.Spec.Template.Spec.TimeoutSeconds = ptr.Int64(timeout)
+		}
+	}
+}
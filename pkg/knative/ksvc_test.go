@@ -0,0 +1,165 @@
+package knative
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+
+	"knative.dev/pkg/ptr"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	fakeserving "knative.dev/serving/pkg/client/clientset/versioned/fake"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	corev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/corev1"
+	teststubknative "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/knative"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+func TestGetKService(t *testing.T) {
+	owner := &servingv1.Service{ObjectMeta: metav1.ObjectMeta{Name: "bar", UID: "1234"}}
+	for _, tc := range []struct {
+		name         string
+		wantKService servingv1.Service
+		inputName    string
+		inputOptions []KServiceOption
+	}{{
+		name: "KService with All Options",
+		wantKService: teststubknative.ConstructExpectedKService(
+			teststubknative.WithPodSpecOptions(
+				teststubcorev1.WithContainerOptions(
+					teststubcorev1.WithImage("docker.com/bar"),
+					teststubcorev1.WithPort(int32(8080))),
+				teststubcorev1.WithServiceAccount("admin-sa")),
+			teststubknative.WithAnnotations(map[string]string{"key1": "val1", "key2": "val2"}),
+			teststubknative.WithLabels(map[string]string{"key1": "val1", "key2": "val2"}),
+			teststubknative.WithOwnerReference(metav1.OwnerReference{
+				APIVersion:         "serving.knative.dev/v1",
+				Kind:               "Service",
+				Name:               "bar",
+				UID:                "1234",
+				Controller:         ptr.Bool(true),
+				BlockOwnerDeletion: ptr.Bool(true),
+			}),
+			teststubknative.WithContainerConcurrency(int64(10)),
+			teststubknative.WithTimeout(int64(300)),
+		),
+		inputName: "foo",
+		inputOptions: []KServiceOption{
+			WithAnnotations([]kubernetes.KV{{"key1", "val1"}, {"key2", "val2"}}),
+			WithLabels([]kubernetes.KV{{"key1", "val1"}, {"key2", "val2"}}),
+			WithOwnerReference(owner),
+			WithContainerConcurrency(int64(10)),
+			WithTimeout(int64(300)),
+			WithPodSpecOptions(kubernetes.PodSpec{},
+				corev1.WithContainerOptions(kubernetes.ContainerSpec{Image: "docker.com/bar"},
+					corev1.WithPort(int32(8080))),
+				corev1.WithServiceAccount("admin-sa")),
+		},
+	}, {
+		name: "KService with null options",
+		wantKService: teststubknative.ConstructExpectedKService(
+			teststubknative.WithPodSpecOptions(
+				teststubcorev1.WithContainerOptions(
+					teststubcorev1.WithImage("docker.com/bar"))),
+			teststubknative.WithContainerConcurrency(int64(0)),
+		),
+		inputName: "foo",
+		inputOptions: []KServiceOption{
+			WithAnnotations([]kubernetes.KV{{"", ""}}),
+			WithLabels(nil),
+			WithContainerConcurrency(int64(0)),
+			WithTimeout(int64(0)),
+			WithPodSpecOptions(kubernetes.PodSpec{},
+				corev1.WithContainerOptions(kubernetes.ContainerSpec{Image: "docker.com/bar"})),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			actKService := GetKService(tc.inputName, tc.inputOptions...)
+			assert.DeepEqual(t, &tc.wantKService, &actKService)
+		})
+	}
+}
+
+func TestApplyKService(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		want           servingv1.Service
+		input          servingv1.Service
+		conflicts      int
+		runtimeObjects []runtime.Object
+	}{{
+		name: "service created when missing",
+		want: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithTimeout(int64(100))),
+		input: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithTimeout(int64(100))),
+	}, {
+		name: "spec updated and server fields preserved",
+		want: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithResourceVersion("2"),
+			teststubknative.WithServiceLabels(map[string]string{"k1": "v1", "k2": "v2"}),
+			teststubknative.WithURL("http://foo.ns.example.com"),
+			teststubknative.WithTimeout(int64(200))),
+		input: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithServiceLabels(map[string]string{"k2": "v2"}),
+			teststubknative.WithTimeout(int64(200))),
+		runtimeObjects: []runtime.Object{ksvcPtr(teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithResourceVersion("2"),
+			teststubknative.WithServiceLabels(map[string]string{"k1": "v1"}),
+			teststubknative.WithURL("http://foo.ns.example.com"),
+			teststubknative.WithTimeout(int64(100))))},
+	}, {
+		name: "update retried on conflict",
+		want: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithTimeout(int64(200))),
+		input: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithTimeout(int64(200))),
+		conflicts: 2,
+		runtimeObjects: []runtime.Object{ksvcPtr(teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithTimeout(int64(100))))},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := fakeserving.NewSimpleClientset(tc.runtimeObjects...)
+			conflicts := tc.conflicts
+			cs.PrependReactor("update", "services", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if conflicts > 0 {
+					conflicts--
+					return true, nil, apierrs.NewConflict(servingv1.Resource("services"), "foo", errors.New("conflict"))
+				}
+				return false, nil, nil
+			})
+			c := kClient{tservingv1: cs.ServingV1(), ctx: context.Background()}
+			act, err := c.ApplyKService("ns", &tc.input)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, &tc.want, act)
+			assert.Equal(t, conflicts, 0)
+		})
+	}
+}
+
+func TestDeleteKService(t *testing.T) {
+	cs := fakeserving.NewSimpleClientset(ksvcPtr(teststubknative.ConstructExpectedKService(teststubknative.WithNamespace("ns"))))
+	c := kClient{tservingv1: cs.ServingV1(), ctx: context.Background()}
+	assert.NilError(t, c.DeleteKService("ns", "foo"))
+	_, err := c.GetKService("ns", "foo")
+	assert.Assert(t, apierrs.IsNotFound(err))
+}
+
+func ksvcPtr(ksvc servingv1.Service) *servingv1.Service {
+	return &ksvc
+}
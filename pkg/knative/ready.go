@@ -0,0 +1,147 @@
+package knative
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+
+	"knative.dev/pkg/apis"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+const (
+	//KSVC_NOT_READY - error message to indicate a service condition turned false
+	KSVC_NOT_READY = "ksvc %s is not ready, condition %s is false, reason: %s, message: %s"
+	//KSVC_READY_TIMEOUT - error message to indicate the service did not become ready in time
+	KSVC_READY_TIMEOUT = "timed out waiting for ksvc %s to be ready, condition %s reason: %s, message: %s"
+	//KSVC_DELETED - error message to indicate the service was deleted while waiting on it
+	KSVC_DELETED = "ksvc %s was deleted before it was ready"
+)
+
+//KServiceNotReadyError - carries the condition which stopped the service from becoming ready
+type KServiceNotReadyError struct {
+	Name      string
+	Condition apis.ConditionType
+	Reason    string
+	Message   string
+	//Timeout is set when the wait ran out before any condition turned false
+	Timeout bool
+}
+
+func (e *KServiceNotReadyError) Error() string {
+	if e.Timeout {
+		return fmt.Sprintf(KSVC_READY_TIMEOUT, e.Name, e.Condition, e.Reason, e.Message)
+	}
+	return fmt.Sprintf(KSVC_NOT_READY, e.Name, e.Condition, e.Reason, e.Message)
+}
+
+var ksvcReadyConditions = []apis.ConditionType{
+	servingv1.ServiceConditionConfigurationsReady,
+	servingv1.ServiceConditionRoutesReady,
+	apis.ConditionReady,
+}
+
+//WaitForKServiceReady watches the knative service till
+//ConfigurationsReady, RoutesReady and Ready are true.
+//If any of the conditions turn false or the timeout expires
+//a *KServiceNotReadyError is returned, a deleted service returns KSVC_DELETED
+func (c kClient) WaitForKServiceReady(ns, name string, timeout time.Duration) (*servingv1.Service, error) {
+	ctx, cancel := context.WithTimeout(c.ctx, timeout)
+	defer cancel()
+
+	ksvc, err := c.tservingv1.Services(ns).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if done, err := checkKServiceReady(ksvc); done {
+			return ksvc, err
+		}
+		if ctx.Err() != nil {
+			return ksvc, notReadyError(ksvc, true)
+		}
+		w, err := c.tservingv1.Services(ns).Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+			ResourceVersion: ksvc.ResourceVersion,
+		})
+		if err != nil {
+			return ksvc, err
+		}
+		ksvc, err = c.watchKService(ctx, w, ksvc)
+		w.Stop()
+		if err != nil {
+			return ksvc, err
+		}
+	}
+}
+
+//watchKService returns once the service is ready, failed, deleted or the watch is closed
+func (c kClient) watchKService(ctx context.Context, w watch.Interface, ksvc *servingv1.Service) (*servingv1.Service, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return ksvc, notReadyError(ksvc, true)
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return ksvc, nil
+			}
+			if event.Type == watch.Error {
+				return ksvc, fmt.Errorf("watching ksvc %s failed: %v", ksvc.Name, event.Object)
+			}
+			updated, ok := event.Object.(*servingv1.Service)
+			if !ok || updated.Name != ksvc.Name {
+				continue
+			}
+			ksvc = updated
+			if event.Type == watch.Deleted {
+				return ksvc, fmt.Errorf(KSVC_DELETED, ksvc.Name)
+			}
+			if done, err := checkKServiceReady(ksvc); done {
+				return ksvc, err
+			}
+		}
+	}
+}
+
+//checkKServiceReady returns true once the service reached a final state
+func checkKServiceReady(ksvc *servingv1.Service) (bool, error) {
+	if ksvc.Status.ObservedGeneration != ksvc.Generation {
+		return false, nil
+	}
+	ready := true
+	for _, t := range ksvcReadyConditions {
+		cond := ksvc.Status.GetCondition(t)
+		if cond.IsFalse() {
+			return true, conditionError(ksvc, t, cond, false)
+		}
+		if !cond.IsTrue() {
+			ready = false
+		}
+	}
+	return ready, nil
+}
+
+//notReadyError reports the first condition which is not true, used when the wait ran out
+func notReadyError(ksvc *servingv1.Service, timeout bool) error {
+	for _, t := range ksvcReadyConditions {
+		if cond := ksvc.Status.GetCondition(t); !cond.IsTrue() {
+			return conditionError(ksvc, t, cond, timeout)
+		}
+	}
+	return conditionError(ksvc, apis.ConditionReady, nil, timeout)
+}
+
+//conditionError reports the reason and message of the condition
+func conditionError(ksvc *servingv1.Service, t apis.ConditionType, cond *apis.Condition, timeout bool) error {
+	return &KServiceNotReadyError{
+		Name:      ksvc.Name,
+		Condition: t,
+		Reason:    cond.GetReason(),
+		Message:   cond.GetMessage(),
+		Timeout:   timeout,
+	}
+}
@@ -0,0 +1,140 @@
+package knative
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	clienttesting "k8s.io/client-go/testing"
+
+	"knative.dev/pkg/apis"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	fakeserving "knative.dev/serving/pkg/client/clientset/versioned/fake"
+
+	teststubknative "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/knative"
+)
+
+func TestWaitForKServiceReady(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		initial servingv1.Service
+		events  []servingv1.Service
+		wantErr *KServiceNotReadyError
+	}{{
+		name: "already ready",
+		initial: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithCondition(servingv1.ServiceConditionConfigurationsReady, corev1.ConditionTrue, "", ""),
+			teststubknative.WithCondition(servingv1.ServiceConditionRoutesReady, corev1.ConditionTrue, "", ""),
+			teststubknative.WithCondition(apis.ConditionReady, corev1.ConditionTrue, "", "")),
+	}, {
+		name: "ready after update",
+		initial: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithGeneration(int64(2), int64(1)),
+			teststubknative.WithCondition(apis.ConditionReady, corev1.ConditionTrue, "", "")),
+		events: []servingv1.Service{
+			teststubknative.ConstructExpectedKService(
+				teststubknative.WithNamespace("ns"),
+				teststubknative.WithGeneration(int64(2), int64(2)),
+				teststubknative.WithCondition(servingv1.ServiceConditionConfigurationsReady, corev1.ConditionTrue, "", ""),
+				teststubknative.WithCondition(servingv1.ServiceConditionRoutesReady, corev1.ConditionUnknown, "", ""),
+				teststubknative.WithCondition(apis.ConditionReady, corev1.ConditionUnknown, "", "")),
+			teststubknative.ConstructExpectedKService(
+				teststubknative.WithNamespace("ns"),
+				teststubknative.WithGeneration(int64(2), int64(2)),
+				teststubknative.WithCondition(servingv1.ServiceConditionConfigurationsReady, corev1.ConditionTrue, "", ""),
+				teststubknative.WithCondition(servingv1.ServiceConditionRoutesReady, corev1.ConditionTrue, "", ""),
+				teststubknative.WithCondition(apis.ConditionReady, corev1.ConditionTrue, "", "")),
+		},
+	}, {
+		name: "revision missing",
+		initial: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns")),
+		events: []servingv1.Service{
+			teststubknative.ConstructExpectedKService(
+				teststubknative.WithNamespace("ns"),
+				teststubknative.WithCondition(servingv1.ServiceConditionConfigurationsReady, corev1.ConditionFalse, "RevisionMissing", "image pull failed"),
+				teststubknative.WithCondition(servingv1.ServiceConditionRoutesReady, corev1.ConditionUnknown, "", ""),
+				teststubknative.WithCondition(apis.ConditionReady, corev1.ConditionFalse, "RevisionMissing", "image pull failed")),
+		},
+		wantErr: &KServiceNotReadyError{
+			Name:      "foo",
+			Condition: servingv1.ServiceConditionConfigurationsReady,
+			Reason:    "RevisionMissing",
+			Message:   "image pull failed",
+		},
+	}, {
+		name: "route failed while configuration is unknown",
+		initial: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns")),
+		events: []servingv1.Service{
+			teststubknative.ConstructExpectedKService(
+				teststubknative.WithNamespace("ns"),
+				teststubknative.WithCondition(servingv1.ServiceConditionConfigurationsReady, corev1.ConditionUnknown, "", ""),
+				teststubknative.WithCondition(servingv1.ServiceConditionRoutesReady, corev1.ConditionFalse, "DomainMappingFailed", "domain is taken"),
+				teststubknative.WithCondition(apis.ConditionReady, corev1.ConditionUnknown, "", "")),
+		},
+		wantErr: &KServiceNotReadyError{
+			Name:      "foo",
+			Condition: servingv1.ServiceConditionRoutesReady,
+			Reason:    "DomainMappingFailed",
+			Message:   "domain is taken",
+		},
+	}, {
+		name: "timeout",
+		initial: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithCondition(servingv1.ServiceConditionConfigurationsReady, corev1.ConditionTrue, "", ""),
+			teststubknative.WithCondition(servingv1.ServiceConditionRoutesReady, corev1.ConditionUnknown, "IngressNotConfigured", "ingress not yet configured")),
+		wantErr: &KServiceNotReadyError{
+			Name:      "foo",
+			Condition: servingv1.ServiceConditionRoutesReady,
+			Reason:    "IngressNotConfigured",
+			Message:   "ingress not yet configured",
+			Timeout:   true,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := fakeserving.NewSimpleClientset(ksvcPtr(tc.initial))
+			fw := watch.NewFake()
+			cs.PrependWatchReactor("services", func(action clienttesting.Action) (bool, watch.Interface, error) {
+				return true, fw, nil
+			})
+			events := tc.events
+			go func() {
+				for i := range events {
+					fw.Modify(&events[i])
+				}
+			}()
+			c := kClient{tservingv1: cs.ServingV1(), ctx: context.Background()}
+			_, err := c.WaitForKServiceReady("ns", "foo", 100*time.Millisecond)
+			if tc.wantErr == nil {
+				assert.NilError(t, err)
+			} else {
+				assert.DeepEqual(t, tc.wantErr, err)
+				assert.Error(t, err, tc.wantErr.Error())
+			}
+		})
+	}
+}
+
+func TestWaitForKServiceDeleted(t *testing.T) {
+	initial := teststubknative.ConstructExpectedKService(
+		teststubknative.WithNamespace("ns"),
+		teststubknative.WithCondition(apis.ConditionReady, corev1.ConditionUnknown, "", ""))
+	cs := fakeserving.NewSimpleClientset(ksvcPtr(initial))
+	fw := watch.NewFake()
+	cs.PrependWatchReactor("services", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		return true, fw, nil
+	})
+	go fw.Delete(ksvcPtr(initial))
+	c := kClient{tservingv1: cs.ServingV1(), ctx: context.Background()}
+	_, err := c.WaitForKServiceReady("ns", "foo", 5*time.Second)
+	assert.Error(t, err, fmt.Sprintf(KSVC_DELETED, "foo"))
+}
@@ -0,0 +1,115 @@
+package knative
+
+import (
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"knative.dev/serving/pkg/apis/serving"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+//RevisionTraffic - revision of a service with the traffic routed to it
+type RevisionTraffic struct {
+	Revision servingv1.Revision
+	Percent  int64
+	Tags     []string
+	//Latest is set for the latest created and latest ready revision
+	Latest bool
+	//InSpec is set when spec.traffic names the revision, the route may not have
+	//caught up with the spec yet so status can still show no traffic
+	InSpec bool
+}
+
+//HasTraffic is true when the revision gets traffic or is reachable through a tag
+func (r RevisionTraffic) HasTraffic() bool {
+	return r.Percent > int64(0) || len(r.Tags) > 0
+}
+
+//ListRevisions returns the revisions of the knative service, newest first
+func (c kClient) ListRevisions(ns, service string) ([]servingv1.Revision, error) {
+	listOpts := metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(labels.Set{serving.ServiceLabelKey: service}).String(),
+	}
+	revisionList, err := c.tservingv1.Revisions(ns).List(c.ctx, listOpts)
+	if err != nil {
+		return nil, err
+	}
+	revisions := revisionList.Items
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return revisions[j].CreationTimestamp.Before(&revisions[i].CreationTimestamp)
+	})
+	return revisions, nil
+}
+
+//GetRevisionTraffic returns the revisions of the knative service, newest first,
+//with the traffic percent and tags from the service spec and status
+func (c kClient) GetRevisionTraffic(ns, service string) ([]RevisionTraffic, error) {
+	ksvc, err := c.GetKService(ns, service)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := c.ListRevisions(ns, service)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := append(ksvc.Status.Traffic, ksvc.Spec.Traffic...)
+	var revTraffic []RevisionTraffic
+	for _, rev := range revisions {
+		rt := RevisionTraffic{
+			Revision: rev,
+			Latest:   rev.Name == ksvc.Status.LatestCreatedRevisionName || rev.Name == ksvc.Status.LatestReadyRevisionName,
+		}
+		for i, target := range targets {
+			if target.RevisionName != rev.Name {
+				continue
+			}
+			if i >= len(ksvc.Status.Traffic) {
+				rt.InSpec = true
+			}
+			//status holds the effective traffic, spec is only used for tags
+			//pinned before the route caught up
+			if target.Percent != nil && i < len(ksvc.Status.Traffic) {
+				rt.Percent += *target.Percent
+			}
+			if target.Tag != "" && !containsString(rt.Tags, target.Tag) {
+				rt.Tags = append(rt.Tags, target.Tag)
+			}
+		}
+		revTraffic = append(revTraffic, rt)
+	}
+	return revTraffic, nil
+}
+
+//DeleteStaleRevisions keeps the newest revisions up to keep and deletes the older
+//ones which get no traffic, have no tags, are not in spec.traffic and are not the latest revision.
+//Names of the deleted revisions are returned
+func (c kClient) DeleteStaleRevisions(ns, service string, keep int) ([]string, error) {
+	revTraffic, err := c.GetRevisionTraffic(ns, service)
+	if err != nil {
+		return nil, err
+	}
+
+	var deleted []string
+	for i, rt := range revTraffic {
+		if i < keep || rt.Latest || rt.InSpec || rt.HasTraffic() {
+			continue
+		}
+		if err := c.tservingv1.Revisions(ns).Delete(c.ctx, rt.Revision.Name, metav1.DeleteOptions{}); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, rt.Revision.Name)
+	}
+	return deleted, nil
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package knative
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	fakeserving "knative.dev/serving/pkg/client/clientset/versioned/fake"
+
+	teststubknative "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/knative"
+)
+
+func revisionObjects() []runtime.Object {
+	return []runtime.Object{
+		ksvcPtr(teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithLatestRevisions("foo-6", "foo-5"),
+			teststubknative.WithTrafficTarget("pinned", "foo-2", false, int64(0)),
+			teststubknative.WithStatusTraffic("", "foo-5", int64(80)),
+			teststubknative.WithStatusTraffic("", "foo-4", int64(20)),
+			teststubknative.WithStatusTraffic("old", "foo-3", int64(0)),
+		)),
+		teststubknative.ConstructRevision("ns", "foo", "foo-1", 6*time.Hour),
+		teststubknative.ConstructRevision("ns", "foo", "foo-2", 5*time.Hour),
+		teststubknative.ConstructRevision("ns", "foo", "foo-3", 4*time.Hour),
+		teststubknative.ConstructRevision("ns", "foo", "foo-4", 3*time.Hour),
+		teststubknative.ConstructRevision("ns", "foo", "foo-5", 2*time.Hour),
+		teststubknative.ConstructRevision("ns", "foo", "foo-6", 1*time.Hour),
+		teststubknative.ConstructRevision("ns", "foo", "foo-0", 7*time.Hour),
+		teststubknative.ConstructRevision("ns", "bar", "bar-1", 7*time.Hour),
+	}
+}
+
+func TestListRevisions(t *testing.T) {
+	c := kClient{tservingv1: fakeserving.NewSimpleClientset(revisionObjects()...).ServingV1(), ctx: context.Background()}
+	revisions, err := c.ListRevisions("ns", "foo")
+	assert.NilError(t, err)
+	var names []string
+	for _, rev := range revisions {
+		names = append(names, rev.Name)
+	}
+	assert.DeepEqual(t, []string{"foo-6", "foo-5", "foo-4", "foo-3", "foo-2", "foo-1", "foo-0"}, names)
+}
+
+func TestGetRevisionTraffic(t *testing.T) {
+	c := kClient{tservingv1: fakeserving.NewSimpleClientset(revisionObjects()...).ServingV1(), ctx: context.Background()}
+	revTraffic, err := c.GetRevisionTraffic("ns", "foo")
+	assert.NilError(t, err)
+
+	type summary struct {
+		Name    string
+		Percent int64
+		Tags    []string
+		Latest  bool
+		InSpec  bool
+	}
+	var act []summary
+	for _, rt := range revTraffic {
+		act = append(act, summary{rt.Revision.Name, rt.Percent, rt.Tags, rt.Latest, rt.InSpec})
+	}
+	assert.DeepEqual(t, []summary{
+		{"foo-6", 0, nil, true, false},
+		{"foo-5", 80, nil, true, false},
+		{"foo-4", 20, nil, false, false},
+		{"foo-3", 0, []string{"old"}, false, false},
+		{"foo-2", 0, []string{"pinned"}, false, true},
+		{"foo-1", 0, nil, false, false},
+		{"foo-0", 0, nil, false, false},
+	}, act)
+}
+
+func TestDeleteStaleRevisions(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		keep        int
+		wantDeleted []string
+	}{{
+		name:        "keep none",
+		keep:        0,
+		wantDeleted: []string{"foo-1", "foo-0"},
+	}, {
+		name:        "keep newest six",
+		keep:        6,
+		wantDeleted: []string{"foo-0"},
+	}, {
+		name: "keep all",
+		keep: 10,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			c := kClient{tservingv1: fakeserving.NewSimpleClientset(revisionObjects()...).ServingV1(), ctx: context.Background()}
+			deleted, err := c.DeleteStaleRevisions("ns", "foo", tc.keep)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.wantDeleted, deleted)
+			revisions, err := c.ListRevisions("ns", "foo")
+			assert.NilError(t, err)
+			assert.Equal(t, len(revisions), 7-len(tc.wantDeleted))
+		})
+	}
+}
+
+func TestDeleteStaleRevisionsSpecAhead(t *testing.T) {
+	//traffic was shifted back to foo-1 but the route still serves foo-5 and foo-4
+	objects := []runtime.Object{
+		ksvcPtr(teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithLatestRevisions("foo-6", "foo-5"),
+			teststubknative.WithTrafficTarget("", "foo-1", false, int64(100)),
+			teststubknative.WithStatusTraffic("", "foo-5", int64(80)),
+			teststubknative.WithStatusTraffic("", "foo-4", int64(20)),
+		)),
+		teststubknative.ConstructRevision("ns", "foo", "foo-1", 6*time.Hour),
+		teststubknative.ConstructRevision("ns", "foo", "foo-2", 5*time.Hour),
+		teststubknative.ConstructRevision("ns", "foo", "foo-4", 3*time.Hour),
+		teststubknative.ConstructRevision("ns", "foo", "foo-5", 2*time.Hour),
+		teststubknative.ConstructRevision("ns", "foo", "foo-6", 1*time.Hour),
+	}
+	c := kClient{tservingv1: fakeserving.NewSimpleClientset(objects...).ServingV1(), ctx: context.Background()}
+	deleted, err := c.DeleteStaleRevisions("ns", "foo", 0)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, []string{"foo-2"}, deleted)
+}
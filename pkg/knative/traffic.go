@@ -0,0 +1,95 @@
+package knative
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"knative.dev/pkg/ptr"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+const (
+	//TRAFFIC_PERCENT_INVALID - error message to indicate traffic does not add up to 100
+	TRAFFIC_PERCENT_INVALID = "traffic percent for ksvc %s should add up to 100, got %d"
+)
+
+//WithRevisionName - name of the revision created from the template,
+//knative expects it to be prefixed with the service name
+func WithRevisionName(name string) KServiceOption {
+	return func(ksvc *servingv1.Service) {
+		if name != "" {
+			ksvc.Spec.Template.ObjectMeta.Name = name
+		}
+	}
+}
+
+//WithRevisionTraffic - route percent of traffic to the named revision,
+//tag is optional and gives the revision its own url
+func WithRevisionTraffic(revisionName string, percent int64, tag string) KServiceOption {
+	return func(ksvc *servingv1.Service) {
+		if revisionName != "" {
+			ksvc.Spec.Traffic = append(ksvc.Spec.Traffic, servingv1.TrafficTarget{
+				Tag:            tag,
+				RevisionName:   revisionName,
+				LatestRevision: ptr.Bool(false),
+				Percent:        ptr.Int64(percent),
+			})
+		}
+	}
+}
+
+//WithLatestRevisionTraffic - route percent of traffic to the latest ready revision,
+//tag is optional and gives the latest revision its own url
+func WithLatestRevisionTraffic(percent int64, tag string) KServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Traffic = append(ksvc.Spec.Traffic, servingv1.TrafficTarget{
+			Tag:            tag,
+			LatestRevision: ptr.Bool(true),
+			Percent:        ptr.Int64(percent),
+		})
+	}
+}
+
+//WithTag - tag the revision without routing traffic to it
+func WithTag(tag, revisionName string) KServiceOption {
+	return func(ksvc *servingv1.Service) {
+		if tag != "" && revisionName != "" {
+			ksvc.Spec.Traffic = append(ksvc.Spec.Traffic, servingv1.TrafficTarget{
+				Tag:            tag,
+				RevisionName:   revisionName,
+				LatestRevision: ptr.Bool(false),
+				Percent:        ptr.Int64(0),
+			})
+		}
+	}
+}
+
+//ShiftTraffic replaces the traffic block of the knative service,
+//only spec.traffic is patched so the template and the revision stay untouched
+func (c kClient) ShiftTraffic(ns, name string, targets []servingv1.TrafficTarget) (*servingv1.Service, error) {
+	if total := trafficPercent(targets); total != int64(100) {
+		return nil, fmt.Errorf(TRAFFIC_PERCENT_INVALID, name, total)
+	}
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"traffic": targets,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.tservingv1.Services(ns).Patch(c.ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+}
+
+func trafficPercent(targets []servingv1.TrafficTarget) int64 {
+	var total int64
+	for _, target := range targets {
+		if target.Percent != nil {
+			total += *target.Percent
+		}
+	}
+	return total
+}
@@ -0,0 +1,94 @@
+package knative
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"gotest.tools/assert"
+
+	"knative.dev/pkg/ptr"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	fakeserving "knative.dev/serving/pkg/client/clientset/versioned/fake"
+
+	teststubknative "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/knative"
+)
+
+func TestTrafficOptions(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		wantKService servingv1.Service
+		inputOptions []KServiceOption
+	}{{
+		name: "canary with pinned revision",
+		wantKService: teststubknative.ConstructExpectedKService(
+			teststubknative.WithRevisionName("foo-v2"),
+			teststubknative.WithTrafficTarget("current", "foo-v1", false, int64(90)),
+			teststubknative.WithTrafficTarget("candidate", "", true, int64(10)),
+			teststubknative.WithTrafficTarget("old", "foo-v0", false, int64(0)),
+		),
+		inputOptions: []KServiceOption{
+			WithRevisionName("foo-v2"),
+			WithRevisionTraffic("foo-v1", int64(90), "current"),
+			WithLatestRevisionTraffic(int64(10), "candidate"),
+			WithTag("old", "foo-v0"),
+		},
+	}, {
+		name:         "null options",
+		wantKService: teststubknative.ConstructExpectedKService(),
+		inputOptions: []KServiceOption{
+			WithRevisionName(""),
+			WithRevisionTraffic("", int64(10), ""),
+			WithTag("", "foo-v0"),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			actKService := GetKService("foo", tc.inputOptions...)
+			assert.DeepEqual(t, &tc.wantKService, &actKService)
+		})
+	}
+}
+
+func TestShiftTraffic(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		want    servingv1.Service
+		input   []servingv1.TrafficTarget
+		wantErr string
+	}{{
+		name: "blue green switch",
+		want: teststubknative.ConstructExpectedKService(
+			teststubknative.WithNamespace("ns"),
+			teststubknative.WithRevisionName("foo-green"),
+			teststubknative.WithTrafficTarget("green", "foo-green", false, int64(100)),
+			teststubknative.WithTrafficTarget("blue", "foo-blue", false, int64(0)),
+		),
+		input: []servingv1.TrafficTarget{
+			{Tag: "green", RevisionName: "foo-green", LatestRevision: ptr.Bool(false), Percent: ptr.Int64(100)},
+			{Tag: "blue", RevisionName: "foo-blue", LatestRevision: ptr.Bool(false), Percent: ptr.Int64(0)},
+		},
+	}, {
+		name: "percent does not add up",
+		input: []servingv1.TrafficTarget{
+			{RevisionName: "foo-green", Percent: ptr.Int64(50)},
+			{RevisionName: "foo-blue", Percent: ptr.Int64(20)},
+		},
+		wantErr: fmt.Sprintf(TRAFFIC_PERCENT_INVALID, "foo", 70),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := fakeserving.NewSimpleClientset(ksvcPtr(teststubknative.ConstructExpectedKService(
+				teststubknative.WithNamespace("ns"),
+				teststubknative.WithRevisionName("foo-green"),
+				teststubknative.WithTrafficTarget("blue", "foo-blue", false, int64(100)),
+			)))
+			c := kClient{tservingv1: cs.ServingV1(), ctx: context.Background()}
+			act, err := c.ShiftTraffic("ns", "foo", tc.input)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+			} else {
+				assert.NilError(t, err)
+				assert.DeepEqual(t, &tc.want, act)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package appsv1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/corev1"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
+)
+
+type DaemonSetOption func(*appsv1.DaemonSet)
+
+//GetDaemonSet construct daemon set spec based on option provided,
+//use corev1.WithNodeSelector and corev1.WithTolerations to pick the nodes
+func GetDaemonSet(name string, options ...DaemonSetOption) appsv1.DaemonSet {
+	daemonSet := appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DaemonSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&daemonSet)
+	}
+	return daemonSet
+}
+
+func WithDaemonSetPodSpecOptions(podSpec kubernetes.PodSpec, options ...corev1.PodSpecOption) DaemonSetOption {
+	return func(daemonSet *appsv1.DaemonSet) {
+		daemonSet.Spec.Template.Spec = corev1.GetPodSpec(podSpec, options...)
+	}
+}
+
+//WithDaemonSetLabels - pod template labels, the selector of the daemon set matches them
+func WithDaemonSetLabels(inLabels []kubernetes.KV) DaemonSetOption {
+	return func(daemonSet *appsv1.DaemonSet) {
+		labels := transform.GetStringMap(inLabels, nil)
+		if len(labels) == 0 {
+			return
+		}
+		daemonSet.Spec.Template.ObjectMeta.Labels = labels
+		daemonSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: transform.GetStringMap(inLabels, nil)}
+	}
+}
+
+//WithDaemonSetRollingUpdate - max unavailable as a number or percent like "10%",
+//empty value is left to the default
+func WithDaemonSetRollingUpdate(maxUnavailable string) DaemonSetOption {
+	return func(daemonSet *appsv1.DaemonSet) {
+		daemonSet.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.RollingUpdateDaemonSetStrategyType,
+		}
+		if maxUnavailable != "" {
+			unavailable := intstr.Parse(maxUnavailable)
+			daemonSet.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateDaemonSet{
+				MaxUnavailable: &unavailable,
+			}
+		}
+	}
+}
+
+//WithDaemonSetOnDelete - pods are only updated when they are deleted
+func WithDaemonSetOnDelete() DaemonSetOption {
+	return func(daemonSet *appsv1.DaemonSet) {
+		daemonSet.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.OnDeleteDaemonSetStrategyType,
+		}
+	}
+}
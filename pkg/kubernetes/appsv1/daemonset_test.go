@@ -0,0 +1,56 @@
+package appsv1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	corev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/corev1"
+	teststubappsv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/appsv1"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+func TestGetDaemonSet(t *testing.T) {
+	maxUnavailable := intstr.FromString("10%")
+	for _, tc := range []struct {
+		name         string
+		want         appsv1.DaemonSet
+		inputOptions []DaemonSetOption
+	}{{
+		name: "daemon set with all options",
+		want: teststubappsv1.ConstructExpectedDaemonSet(
+			teststubappsv1.WithDaemonSetPodSpecOptions(
+				teststubcorev1.WithContainerOptions(teststubcorev1.WithImage("agent")),
+				teststubcorev1.WithNodeSelector(map[string]string{"kubernetes.io/os": "linux"}),
+				teststubcorev1.WithTolerations([]corev1api.Toleration{{Key: "node-role.kubernetes.io/master", Effect: corev1api.TaintEffectNoSchedule}})),
+			teststubappsv1.WithDaemonSetLabels(map[string]string{"app": "agent"}),
+			teststubappsv1.WithDaemonSetUpdateStrategy(appsv1.RollingUpdateDaemonSetStrategyType, &maxUnavailable)),
+		inputOptions: []DaemonSetOption{
+			WithDaemonSetPodSpecOptions(kubernetes.PodSpec{},
+				corev1.WithContainerOptions(kubernetes.ContainerSpec{Image: "agent"}),
+				corev1.WithNodeSelector([]kubernetes.KV{{"kubernetes.io/os", "linux"}}),
+				corev1.WithTolerations([]corev1api.Toleration{{Key: "node-role.kubernetes.io/master", Effect: corev1api.TaintEffectNoSchedule}})),
+			WithDaemonSetLabels([]kubernetes.KV{{"app", "agent"}}),
+			WithDaemonSetRollingUpdate("10%"),
+		},
+	}, {
+		name: "daemon set with null options",
+		want: teststubappsv1.ConstructExpectedDaemonSet(
+			teststubappsv1.WithDaemonSetUpdateStrategy(appsv1.RollingUpdateDaemonSetStrategyType, nil)),
+		inputOptions: []DaemonSetOption{
+			WithDaemonSetLabels(nil),
+			WithDaemonSetOnDelete(),
+			WithDaemonSetRollingUpdate(""),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			act := GetDaemonSet("foo", tc.inputOptions...)
+			assert.DeepEqual(t, &tc.want, &act)
+		})
+	}
+}
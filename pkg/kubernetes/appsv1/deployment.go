@@ -0,0 +1,184 @@
+package appsv1
+
+import (
+	"context"
+	"encoding/json"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	typedappsv1 "k8s.io/client-go/kubernetes/typed/apps/v1"
+	"k8s.io/client-go/util/retry"
+
+	"knative.dev/pkg/kmeta"
+	"knative.dev/pkg/ptr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/corev1"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
+)
+
+type DeploymentOption func(*appsv1.Deployment)
+
+type appsClient struct {
+	tappsv1 typedappsv1.AppsV1Interface
+	ctx     context.Context
+}
+
+func Client(c context.Context) *appsClient {
+	cs := kubernetes.KubernetesCSFromContext(c)
+	return &appsClient{
+		tappsv1: cs.AppsV1(),
+		ctx:     c,
+	}
+}
+
+//GetDeployment returns the deployment for the name and namespace
+func (c *appsClient) GetDeployment(ns, name string) (*appsv1.Deployment, error) {
+	return c.tappsv1.Deployments(ns).Get(c.ctx, name, metav1.GetOptions{})
+}
+
+//CreateDeployment creates the deployment in the namespace
+func (c *appsClient) CreateDeployment(ns string, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	return c.tappsv1.Deployments(ns).Create(c.ctx, deployment, metav1.CreateOptions{})
+}
+
+//ApplyDeployment creates the deployment if it is not present otherwise updates
+//the spec, labels and annotations are copied on to the existing deployment.
+//update is retried on conflict
+func (c *appsClient) ApplyDeployment(ns string, deployment *appsv1.Deployment) (*appsv1.Deployment, error) {
+	var applied *appsv1.Deployment
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.GetDeployment(ns, deployment.Name)
+		if apierrs.IsNotFound(err) {
+			applied, err = c.CreateDeployment(ns, deployment)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		desired := existing.DeepCopy()
+		desired.Spec = *deployment.Spec.DeepCopy()
+		transform.ApplyMeta(&desired.ObjectMeta, deployment.ObjectMeta)
+		applied, err = c.tappsv1.Deployments(ns).Update(c.ctx, desired, metav1.UpdateOptions{})
+		return err
+	})
+	return applied, err
+}
+
+//ScaleDeployment sets the replicas of the deployment, only spec.replicas is patched
+func (c *appsClient) ScaleDeployment(ns, name string, replicas int32) (*appsv1.Deployment, error) {
+	patch, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.tappsv1.Deployments(ns).Patch(c.ctx, name, types.MergePatchType, patch, metav1.PatchOptions{})
+}
+
+//GetDeployment construct deployment spec based on option provided
+func GetDeployment(name string, options ...DeploymentOption) appsv1.Deployment {
+	deployment := appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&deployment)
+	}
+	return deployment
+}
+
+func WithDeploymentPodSpecOptions(podSpec kubernetes.PodSpec, options ...corev1.PodSpecOption) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.Template.Spec = corev1.GetPodSpec(podSpec, options...)
+	}
+}
+
+//WithDeploymentReplicas - number of pods, 0 scales the deployment down
+func WithDeploymentReplicas(replicas int32) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		if replicas >= int32(0) {
+			deployment.Spec.Replicas = ptr.Int32(replicas)
+		}
+	}
+}
+
+//WithDeploymentLabels - pod template labels, the selector of the deployment matches them
+func WithDeploymentLabels(inLabels []kubernetes.KV) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		labels := transform.GetStringMap(inLabels, nil)
+		if len(labels) == 0 {
+			return
+		}
+		deployment.Spec.Template.ObjectMeta.Labels = labels
+		deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: transform.GetStringMap(inLabels, nil)}
+	}
+}
+
+func WithDeploymentAnnotations(inAnnotations []kubernetes.KV) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.Template.ObjectMeta.Annotations = transform.GetStringMap(inAnnotations, nil)
+	}
+}
+
+//WithDeploymentRollingUpdate - max surge and max unavailable as a number or percent like "25%",
+//empty values are left to the defaults
+func WithDeploymentRollingUpdate(maxSurge, maxUnavailable string) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		rollingUpdate := &appsv1.RollingUpdateDeployment{}
+		if maxSurge != "" {
+			surge := intstr.Parse(maxSurge)
+			rollingUpdate.MaxSurge = &surge
+		}
+		if maxUnavailable != "" {
+			unavailable := intstr.Parse(maxUnavailable)
+			rollingUpdate.MaxUnavailable = &unavailable
+		}
+		deployment.Spec.Strategy = appsv1.DeploymentStrategy{
+			Type:          appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: rollingUpdate,
+		}
+	}
+}
+
+//WithDeploymentRecreate - all old pods are removed before the new ones are created
+func WithDeploymentRecreate() DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	}
+}
+
+//WithDeploymentRevisionHistoryLimit - number of old replica sets kept for rollback
+func WithDeploymentRevisionHistoryLimit(limit int32) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		if limit >= int32(0) {
+			deployment.Spec.RevisionHistoryLimit = ptr.Int32(limit)
+		}
+	}
+}
+
+//WithDeploymentProgressDeadline - seconds after which a stalled rollout is reported as ProgressDeadlineExceeded
+func WithDeploymentProgressDeadline(seconds int32) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		if seconds > int32(0) {
+			deployment.Spec.ProgressDeadlineSeconds = ptr.Int32(seconds)
+		}
+	}
+}
+
+func WithDeploymentOwnerReference(obj kmeta.OwnerRefable) DeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		ownerRef := metav1.NewControllerRef(obj.GetObjectMeta(), obj.GetGroupVersionKind())
+		deployment.ObjectMeta.OwnerReferences = []metav1.OwnerReference{*ownerRef}
+	}
+}
@@ -0,0 +1,122 @@
+package appsv1
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	corev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/corev1"
+	teststubappsv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/appsv1"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+func TestGetDeployment(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		want         appsv1.Deployment
+		inputOptions []DeploymentOption
+	}{{
+		name: "deployment with all options",
+		want: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithPodSpecOptions(
+				teststubcorev1.WithContainerOptions(teststubcorev1.WithImage("app")),
+				teststubcorev1.WithServiceAccount("app-sa")),
+			teststubappsv1.WithReplicas(int32(3)),
+			teststubappsv1.WithLabels(map[string]string{"app": "foo", "tier": "web"}),
+			teststubappsv1.WithRollingUpdate(intstr.FromString("25%"), intstr.FromInt(0)),
+			teststubappsv1.WithRevisionHistoryLimit(int32(5)),
+			teststubappsv1.WithProgressDeadline(int32(120))),
+		inputOptions: []DeploymentOption{
+			WithDeploymentPodSpecOptions(kubernetes.PodSpec{},
+				corev1.WithContainerOptions(kubernetes.ContainerSpec{Image: "app"}),
+				corev1.WithServiceAccount("app-sa")),
+			WithDeploymentReplicas(int32(3)),
+			WithDeploymentLabels([]kubernetes.KV{{"app", "foo"}, {"tier", "web"}}),
+			WithDeploymentRollingUpdate("25%", "0"),
+			WithDeploymentRevisionHistoryLimit(int32(5)),
+			WithDeploymentProgressDeadline(int32(120)),
+		},
+	}, {
+		name: "deployment with null options",
+		want: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithReplicas(int32(0)),
+			teststubappsv1.WithRecreate()),
+		inputOptions: []DeploymentOption{
+			WithDeploymentReplicas(int32(0)),
+			WithDeploymentLabels(nil),
+			WithDeploymentRevisionHistoryLimit(int32(-1)),
+			WithDeploymentProgressDeadline(int32(0)),
+			WithDeploymentRecreate(),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			act := GetDeployment("foo", tc.inputOptions...)
+			assert.DeepEqual(t, &tc.want, &act)
+		})
+	}
+}
+
+func TestApplyDeployment(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		want           appsv1.Deployment
+		input          appsv1.Deployment
+		runtimeObjects []runtime.Object
+	}{{
+		name: "deployment created when missing",
+		want: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithReplicas(int32(2))),
+		input: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithReplicas(int32(2))),
+	}, {
+		name: "spec updated and status preserved",
+		want: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithDeploymentLabels(map[string]string{"k1": "v1", "k2": "v2"}),
+			teststubappsv1.WithReplicaStatus(int32(2), int32(2), int32(2), int32(2)),
+			teststubappsv1.WithReplicas(int32(4))),
+		input: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithDeploymentLabels(map[string]string{"k2": "v2"}),
+			teststubappsv1.WithReplicas(int32(4))),
+		runtimeObjects: []runtime.Object{deploymentPtr(teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithDeploymentLabels(map[string]string{"k1": "v1"}),
+			teststubappsv1.WithReplicaStatus(int32(2), int32(2), int32(2), int32(2)),
+			teststubappsv1.WithReplicas(int32(2))))},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := testclient.NewSimpleClientset(tc.runtimeObjects...)
+			c := &appsClient{tappsv1: cs.AppsV1(), ctx: context.Background()}
+			act, err := c.ApplyDeployment("ns", &tc.input)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, &tc.want, act)
+		})
+	}
+}
+
+func TestScaleDeployment(t *testing.T) {
+	cs := testclient.NewSimpleClientset(deploymentPtr(teststubappsv1.ConstructExpectedDeployment(
+		teststubappsv1.WithNamespace("ns"),
+		teststubappsv1.WithReplicas(int32(2)))))
+	c := &appsClient{tappsv1: cs.AppsV1(), ctx: context.Background()}
+	act, err := c.ScaleDeployment("ns", "foo", int32(5))
+	assert.NilError(t, err)
+	assert.Equal(t, *act.Spec.Replicas, int32(5))
+
+	_, err = c.ScaleDeployment("ns", "bar", int32(5))
+	assert.ErrorContains(t, err, "not found")
+}
+
+func deploymentPtr(deployment appsv1.Deployment) *appsv1.Deployment {
+	return &deployment
+}
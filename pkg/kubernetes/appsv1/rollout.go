@@ -0,0 +1,349 @@
+package appsv1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	//ROLLOUT_FAILED - error message to indicate the rollout stopped progressing
+	ROLLOUT_FAILED = "rollout of %s %s failed, reason: %s, message: %s"
+	//ROLLOUT_TIMEOUT - error message to indicate the rollout did not finish in time
+	ROLLOUT_TIMEOUT = "rollout of %s %s did not finish in %v: %s"
+	//ROLLOUT_STRATEGY_UNSUPPORTED - error message to indicate the rollout can not be watched
+	ROLLOUT_STRATEGY_UNSUPPORTED = "rollout status is only available for %s strategy, %s %s uses %s"
+	//ROLLOUT_DELETED - error message to indicate the workload was deleted during the rollout
+	ROLLOUT_DELETED = "%s %s was deleted during the rollout"
+	//progressDeadlineExceeded - reason of the Progressing condition when the deadline passed
+	progressDeadlineExceeded = "ProgressDeadlineExceeded"
+)
+
+//RolloutKind - workload kinds WaitForRollout supports
+type RolloutKind string
+
+const (
+	DeploymentKind  RolloutKind = "Deployment"
+	StatefulSetKind RolloutKind = "StatefulSet"
+	DaemonSetKind   RolloutKind = "DaemonSet"
+)
+
+//RolloutProgress - replica counts of the workload as seen during the rollout,
+//for daemon sets the counts are scheduled pods
+type RolloutProgress struct {
+	Kind               RolloutKind
+	Name               string
+	Generation         int64
+	ObservedGeneration int64
+	Desired            int32
+	Updated            int32
+	Ready              int32
+	Available          int32
+	//Message describes what the rollout waits for, like kubectl rollout status
+	Message string
+	Done    bool
+}
+
+//RolloutFailedError - carries the reason from the Progressing condition of the deployment
+type RolloutFailedError struct {
+	Kind    RolloutKind
+	Name    string
+	Reason  string
+	Message string
+}
+
+func (e *RolloutFailedError) Error() string {
+	return fmt.Sprintf(ROLLOUT_FAILED, e.Kind, e.Name, e.Reason, e.Message)
+}
+
+//RolloutTimeoutError - the rollout did not finish in time, Last is the last seen progress
+type RolloutTimeoutError struct {
+	Timeout time.Duration
+	Last    RolloutProgress
+}
+
+func (e *RolloutTimeoutError) Error() string {
+	return fmt.Sprintf(ROLLOUT_TIMEOUT, e.Last.Kind, e.Last.Name, e.Timeout, e.Last.Message)
+}
+
+//rollout gets, watches and checks one kind of workload
+type rollout struct {
+	get    func(ctx context.Context) (runtime.Object, error)
+	watch  func(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+	status func(obj runtime.Object) (RolloutProgress, error)
+}
+
+//WaitForRollout watches the deployment, stateful set or daemon set till the rollout is complete,
+//the same checks as kubectl rollout status. onProgress, when not nil, is called with every
+//observed state. A timeout of 0 waits till the client context is cancelled, a timeout
+//returns *RolloutTimeoutError and a deployment past its progress deadline *RolloutFailedError
+func (c *appsClient) WaitForRollout(ns string, kind RolloutKind, name string, timeout time.Duration, onProgress func(RolloutProgress)) (RolloutProgress, error) {
+	r, err := c.rolloutFor(ns, kind, name)
+	if err != nil {
+		return RolloutProgress{Kind: kind, Name: name}, err
+	}
+	ctx, cancel := c.ctx, context.CancelFunc(func() {})
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(c.ctx, timeout)
+	}
+	defer cancel()
+
+	_, progress, err := waitForRollout(ctx, r, onProgress)
+	if err == context.DeadlineExceeded && c.ctx.Err() == nil {
+		return progress, &RolloutTimeoutError{Timeout: timeout, Last: progress}
+	}
+	return progress, err
+}
+
+//WaitForDeploymentRollout watches the deployment till all replicas are updated and available,
+//see WaitForRollout. Cancelling the client context stops the wait
+func (c *appsClient) WaitForDeploymentRollout(ns, name string) (*appsv1.Deployment, error) {
+	r, _ := c.rolloutFor(ns, DeploymentKind, name)
+	obj, _, err := waitForRollout(c.ctx, r, nil)
+	deployment, _ := obj.(*appsv1.Deployment)
+	return deployment, err
+}
+
+func (c *appsClient) rolloutFor(ns string, kind RolloutKind, name string) (rollout, error) {
+	switch kind {
+	case DeploymentKind:
+		return rollout{
+			get: func(ctx context.Context) (runtime.Object, error) {
+				return c.tappsv1.Deployments(ns).Get(ctx, name, metav1.GetOptions{})
+			},
+			watch:  c.tappsv1.Deployments(ns).Watch,
+			status: deploymentStatus,
+		}, nil
+	case StatefulSetKind:
+		return rollout{
+			get: func(ctx context.Context) (runtime.Object, error) {
+				return c.tappsv1.StatefulSets(ns).Get(ctx, name, metav1.GetOptions{})
+			},
+			watch:  c.tappsv1.StatefulSets(ns).Watch,
+			status: statefulSetStatus,
+		}, nil
+	case DaemonSetKind:
+		return rollout{
+			get: func(ctx context.Context) (runtime.Object, error) {
+				return c.tappsv1.DaemonSets(ns).Get(ctx, name, metav1.GetOptions{})
+			},
+			watch:  c.tappsv1.DaemonSets(ns).Watch,
+			status: daemonSetStatus,
+		}, nil
+	}
+	return rollout{}, fmt.Errorf("rollout status is not supported for kind %s", kind)
+}
+
+func waitForRollout(ctx context.Context, r rollout, onProgress func(RolloutProgress)) (runtime.Object, RolloutProgress, error) {
+	obj, err := r.get(ctx)
+	if err != nil {
+		return nil, RolloutProgress{}, err
+	}
+	for {
+		progress, err := r.status(obj)
+		if onProgress != nil && err == nil {
+			onProgress(progress)
+		}
+		if progress.Done || err != nil {
+			return obj, progress, err
+		}
+		if err := ctx.Err(); err != nil {
+			return obj, progress, err
+		}
+		meta, err := metaAccessor(obj)
+		if err != nil {
+			return obj, progress, err
+		}
+		w, err := r.watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", meta.GetName()).String(),
+			ResourceVersion: meta.GetResourceVersion(),
+		})
+		if err != nil {
+			return obj, progress, err
+		}
+		obj, progress, err = watchRollout(ctx, w, r, obj, progress, onProgress)
+		w.Stop()
+		if progress.Done || err != nil {
+			return obj, progress, err
+		}
+	}
+}
+
+//watchRollout returns once the rollout finished, the workload was deleted or the watch is closed
+func watchRollout(ctx context.Context, w watch.Interface, r rollout, obj runtime.Object, progress RolloutProgress, onProgress func(RolloutProgress)) (runtime.Object, RolloutProgress, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return obj, progress, ctx.Err()
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return obj, progress, nil
+			}
+			if event.Type == watch.Error {
+				return obj, progress, fmt.Errorf("watching %s %s failed: %v", progress.Kind, progress.Name, event.Object)
+			}
+			updated, err := metaAccessor(event.Object)
+			if err != nil || updated.GetName() != progress.Name {
+				continue
+			}
+			obj = event.Object
+			if event.Type == watch.Deleted {
+				return obj, progress, fmt.Errorf(ROLLOUT_DELETED, progress.Kind, progress.Name)
+			}
+			updatedProgress, err := r.status(obj)
+			if err != nil {
+				return obj, progress, err
+			}
+			progress = updatedProgress
+			if onProgress != nil {
+				onProgress(progress)
+			}
+			if progress.Done {
+				return obj, progress, nil
+			}
+		}
+	}
+}
+
+func metaAccessor(obj runtime.Object) (metav1.Object, error) {
+	meta, ok := obj.(metav1.Object)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object %T", obj)
+	}
+	return meta, nil
+}
+
+func deploymentStatus(obj runtime.Object) (RolloutProgress, error) {
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return RolloutProgress{}, fmt.Errorf("unexpected object %T", obj)
+	}
+	status := deployment.Status
+	progress := RolloutProgress{
+		Kind:               DeploymentKind,
+		Name:               deployment.Name,
+		Generation:         deployment.Generation,
+		ObservedGeneration: status.ObservedGeneration,
+		Desired:            status.Replicas,
+		Updated:            status.UpdatedReplicas,
+		Ready:              status.ReadyReplicas,
+		Available:          status.AvailableReplicas,
+	}
+	if deployment.Spec.Replicas != nil {
+		progress.Desired = *deployment.Spec.Replicas
+	}
+	if deployment.Generation > status.ObservedGeneration {
+		progress.Message = fmt.Sprintf("waiting for deployment %q spec update to be observed", deployment.Name)
+		return progress, nil
+	}
+	for _, cond := range status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Reason == progressDeadlineExceeded {
+			return progress, &RolloutFailedError{
+				Kind:    DeploymentKind,
+				Name:    deployment.Name,
+				Reason:  cond.Reason,
+				Message: cond.Message,
+			}
+		}
+	}
+	switch {
+	case deployment.Spec.Replicas != nil && status.UpdatedReplicas < *deployment.Spec.Replicas:
+		progress.Message = fmt.Sprintf("%d out of %d new replicas have been updated", status.UpdatedReplicas, *deployment.Spec.Replicas)
+	case status.Replicas > status.UpdatedReplicas:
+		progress.Message = fmt.Sprintf("%d old replicas are pending termination", status.Replicas-status.UpdatedReplicas)
+	case status.AvailableReplicas < status.UpdatedReplicas:
+		progress.Message = fmt.Sprintf("%d of %d updated replicas are available", status.AvailableReplicas, status.UpdatedReplicas)
+	default:
+		progress.Message = fmt.Sprintf("deployment %q successfully rolled out", deployment.Name)
+		progress.Done = true
+	}
+	return progress, nil
+}
+
+func statefulSetStatus(obj runtime.Object) (RolloutProgress, error) {
+	statefulSet, ok := obj.(*appsv1.StatefulSet)
+	if !ok {
+		return RolloutProgress{}, fmt.Errorf("unexpected object %T", obj)
+	}
+	status := statefulSet.Status
+	progress := RolloutProgress{
+		Kind:               StatefulSetKind,
+		Name:               statefulSet.Name,
+		Generation:         statefulSet.Generation,
+		ObservedGeneration: status.ObservedGeneration,
+		Desired:            status.Replicas,
+		Updated:            status.UpdatedReplicas,
+		Ready:              status.ReadyReplicas,
+		Available:          status.ReadyReplicas,
+	}
+	if statefulSet.Spec.UpdateStrategy.Type != appsv1.RollingUpdateStatefulSetStrategyType {
+		return progress, fmt.Errorf(ROLLOUT_STRATEGY_UNSUPPORTED, appsv1.RollingUpdateStatefulSetStrategyType, StatefulSetKind, statefulSet.Name, statefulSet.Spec.UpdateStrategy.Type)
+	}
+	if statefulSet.Spec.Replicas != nil {
+		progress.Desired = *statefulSet.Spec.Replicas
+	}
+	if status.ObservedGeneration == 0 || statefulSet.Generation > status.ObservedGeneration {
+		progress.Message = fmt.Sprintf("waiting for statefulset %q spec update to be observed", statefulSet.Name)
+		return progress, nil
+	}
+	if status.ReadyReplicas < progress.Desired {
+		progress.Message = fmt.Sprintf("%d of %d pods are ready", status.ReadyReplicas, progress.Desired)
+		return progress, nil
+	}
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate != nil && rollingUpdate.Partition != nil && *rollingUpdate.Partition > 0 {
+		if want := progress.Desired - *rollingUpdate.Partition; status.UpdatedReplicas < want {
+			progress.Message = fmt.Sprintf("%d of %d new pods have been updated", status.UpdatedReplicas, want)
+			return progress, nil
+		}
+		progress.Message = fmt.Sprintf("partitioned roll out complete: %d new pods have been updated", status.UpdatedReplicas)
+		progress.Done = true
+		return progress, nil
+	}
+	if status.UpdateRevision != status.CurrentRevision {
+		progress.Message = fmt.Sprintf("%d pods at revision %s", status.UpdatedReplicas, status.UpdateRevision)
+		return progress, nil
+	}
+	progress.Message = fmt.Sprintf("statefulset %q successfully rolled out", statefulSet.Name)
+	progress.Done = true
+	return progress, nil
+}
+
+func daemonSetStatus(obj runtime.Object) (RolloutProgress, error) {
+	daemonSet, ok := obj.(*appsv1.DaemonSet)
+	if !ok {
+		return RolloutProgress{}, fmt.Errorf("unexpected object %T", obj)
+	}
+	status := daemonSet.Status
+	progress := RolloutProgress{
+		Kind:               DaemonSetKind,
+		Name:               daemonSet.Name,
+		Generation:         daemonSet.Generation,
+		ObservedGeneration: status.ObservedGeneration,
+		Desired:            status.DesiredNumberScheduled,
+		Updated:            status.UpdatedNumberScheduled,
+		Ready:              status.NumberReady,
+		Available:          status.NumberAvailable,
+	}
+	if daemonSet.Spec.UpdateStrategy.Type != appsv1.RollingUpdateDaemonSetStrategyType {
+		return progress, fmt.Errorf(ROLLOUT_STRATEGY_UNSUPPORTED, appsv1.RollingUpdateDaemonSetStrategyType, DaemonSetKind, daemonSet.Name, daemonSet.Spec.UpdateStrategy.Type)
+	}
+	switch {
+	case daemonSet.Generation > status.ObservedGeneration:
+		progress.Message = fmt.Sprintf("waiting for daemon set %q spec update to be observed", daemonSet.Name)
+	case status.UpdatedNumberScheduled < status.DesiredNumberScheduled:
+		progress.Message = fmt.Sprintf("%d out of %d new pods have been updated", status.UpdatedNumberScheduled, status.DesiredNumberScheduled)
+	case status.NumberAvailable < status.DesiredNumberScheduled:
+		progress.Message = fmt.Sprintf("%d of %d updated pods are available", status.NumberAvailable, status.DesiredNumberScheduled)
+	default:
+		progress.Message = fmt.Sprintf("daemon set %q successfully rolled out", daemonSet.Name)
+		progress.Done = true
+	}
+	return progress, nil
+}
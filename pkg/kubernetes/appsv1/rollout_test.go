@@ -0,0 +1,275 @@
+package appsv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"knative.dev/pkg/ptr"
+
+	teststubappsv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/appsv1"
+)
+
+func TestWaitForDeploymentRollout(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		initial appsv1.Deployment
+		events  []appsv1.Deployment
+		want    appsv1.Deployment
+		wantErr error
+	}{{
+		name: "already rolled out",
+		initial: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithReplicas(int32(2)),
+			teststubappsv1.WithGeneration(int64(2), int64(2)),
+			teststubappsv1.WithReplicaStatus(int32(2), int32(2), int32(2), int32(2))),
+		want: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithReplicas(int32(2)),
+			teststubappsv1.WithGeneration(int64(2), int64(2)),
+			teststubappsv1.WithReplicaStatus(int32(2), int32(2), int32(2), int32(2))),
+	}, {
+		name: "old replicas terminated and new ones available",
+		initial: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithReplicas(int32(2)),
+			teststubappsv1.WithGeneration(int64(2), int64(1))),
+		events: []appsv1.Deployment{
+			teststubappsv1.ConstructExpectedDeployment(
+				teststubappsv1.WithNamespace("ns"),
+				teststubappsv1.WithReplicas(int32(2)),
+				teststubappsv1.WithGeneration(int64(2), int64(2)),
+				teststubappsv1.WithReplicaStatus(int32(3), int32(2), int32(2), int32(2))),
+			teststubappsv1.ConstructExpectedDeployment(
+				teststubappsv1.WithNamespace("ns"),
+				teststubappsv1.WithReplicas(int32(2)),
+				teststubappsv1.WithGeneration(int64(2), int64(2)),
+				teststubappsv1.WithReplicaStatus(int32(2), int32(2), int32(2), int32(1))),
+			teststubappsv1.ConstructExpectedDeployment(
+				teststubappsv1.WithNamespace("ns"),
+				teststubappsv1.WithReplicas(int32(2)),
+				teststubappsv1.WithGeneration(int64(2), int64(2)),
+				teststubappsv1.WithReplicaStatus(int32(2), int32(2), int32(2), int32(2))),
+		},
+		want: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithReplicas(int32(2)),
+			teststubappsv1.WithGeneration(int64(2), int64(2)),
+			teststubappsv1.WithReplicaStatus(int32(2), int32(2), int32(2), int32(2))),
+	}, {
+		name: "progress deadline exceeded",
+		initial: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithReplicas(int32(2))),
+		events: []appsv1.Deployment{
+			teststubappsv1.ConstructExpectedDeployment(
+				teststubappsv1.WithNamespace("ns"),
+				teststubappsv1.WithReplicas(int32(2)),
+				teststubappsv1.WithReplicaStatus(int32(2), int32(1), int32(1), int32(1)),
+				teststubappsv1.WithProgressingCondition("ProgressDeadlineExceeded", `ReplicaSet "foo-1" has timed out progressing.`)),
+		},
+		want: teststubappsv1.ConstructExpectedDeployment(
+			teststubappsv1.WithNamespace("ns"),
+			teststubappsv1.WithReplicas(int32(2)),
+			teststubappsv1.WithReplicaStatus(int32(2), int32(1), int32(1), int32(1)),
+			teststubappsv1.WithProgressingCondition("ProgressDeadlineExceeded", `ReplicaSet "foo-1" has timed out progressing.`)),
+		wantErr: &RolloutFailedError{
+			Kind:    DeploymentKind,
+			Name:    "foo",
+			Reason:  "ProgressDeadlineExceeded",
+			Message: `ReplicaSet "foo-1" has timed out progressing.`,
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := testclient.NewSimpleClientset(&tc.initial)
+			fw := watch.NewFake()
+			cs.PrependWatchReactor("deployments", func(action clienttesting.Action) (bool, watch.Interface, error) {
+				return true, fw, nil
+			})
+			events := tc.events
+			go func() {
+				for i := range events {
+					fw.Modify(&events[i])
+				}
+			}()
+			c := &appsClient{tappsv1: cs.AppsV1(), ctx: context.Background()}
+			act, err := c.WaitForDeploymentRollout("ns", "foo")
+			if tc.wantErr == nil {
+				assert.NilError(t, err)
+			} else {
+				assert.DeepEqual(t, tc.wantErr, err)
+			}
+			assert.DeepEqual(t, &tc.want, act)
+		})
+	}
+}
+
+func TestWaitForDeploymentRolloutCancelled(t *testing.T) {
+	deployment := teststubappsv1.ConstructExpectedDeployment(
+		teststubappsv1.WithNamespace("ns"),
+		teststubappsv1.WithReplicas(int32(2)))
+	cs := testclient.NewSimpleClientset(&deployment)
+	cs.PrependWatchReactor("deployments", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		return true, watch.NewFake(), nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c := &appsClient{tappsv1: cs.AppsV1(), ctx: ctx}
+	_, err := c.WaitForDeploymentRollout("ns", "foo")
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestWaitForRollout(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		kind         RolloutKind
+		initial      runtime.Object
+		events       []runtime.Object
+		wantProgress []string
+		wantErr      string
+	}{{
+		name: "stateful set revision rolled out",
+		kind: StatefulSetKind,
+		initial: statefulSetPtr(teststubappsv1.ConstructExpectedStatefulSet(
+			teststubappsv1.WithStatefulSetNamespace("ns"),
+			teststubappsv1.WithStatefulSetReplicas(int32(2)),
+			teststubappsv1.WithStatefulSetUpdateStrategy(appsv1.RollingUpdateStatefulSetStrategyType, nil),
+			teststubappsv1.WithStatefulSetStatus(int64(2), int64(2), int32(1), int32(2), "foo-1", "foo-2"))),
+		events: []runtime.Object{statefulSetPtr(teststubappsv1.ConstructExpectedStatefulSet(
+			teststubappsv1.WithStatefulSetNamespace("ns"),
+			teststubappsv1.WithStatefulSetReplicas(int32(2)),
+			teststubappsv1.WithStatefulSetUpdateStrategy(appsv1.RollingUpdateStatefulSetStrategyType, nil),
+			teststubappsv1.WithStatefulSetStatus(int64(2), int64(2), int32(2), int32(2), "foo-2", "foo-2")))},
+		wantProgress: []string{
+			"1 pods at revision foo-2",
+			`statefulset "foo" successfully rolled out`,
+		},
+	}, {
+		name: "stateful set partitioned rollout",
+		kind: StatefulSetKind,
+		initial: statefulSetPtr(teststubappsv1.ConstructExpectedStatefulSet(
+			teststubappsv1.WithStatefulSetNamespace("ns"),
+			teststubappsv1.WithStatefulSetReplicas(int32(3)),
+			teststubappsv1.WithStatefulSetUpdateStrategy(appsv1.RollingUpdateStatefulSetStrategyType, ptr.Int32(2)),
+			teststubappsv1.WithStatefulSetStatus(int64(1), int64(1), int32(1), int32(3), "foo-1", "foo-2"))),
+		wantProgress: []string{
+			"partitioned roll out complete: 1 new pods have been updated",
+		},
+	}, {
+		name: "stateful set on delete not supported",
+		kind: StatefulSetKind,
+		initial: statefulSetPtr(teststubappsv1.ConstructExpectedStatefulSet(
+			teststubappsv1.WithStatefulSetNamespace("ns"),
+			teststubappsv1.WithStatefulSetUpdateStrategy(appsv1.OnDeleteStatefulSetStrategyType, nil))),
+		wantErr: "rollout status is only available for RollingUpdate strategy, StatefulSet foo uses OnDelete",
+	}, {
+		name: "daemon set rolled out",
+		kind: DaemonSetKind,
+		initial: daemonSetPtr(teststubappsv1.ConstructExpectedDaemonSet(
+			teststubappsv1.WithDaemonSetNamespace("ns"),
+			teststubappsv1.WithDaemonSetUpdateStrategy(appsv1.RollingUpdateDaemonSetStrategyType, nil),
+			teststubappsv1.WithDaemonSetStatus(int64(3), int64(2), int32(3), int32(3), int32(3)))),
+		events: []runtime.Object{
+			daemonSetPtr(teststubappsv1.ConstructExpectedDaemonSet(
+				teststubappsv1.WithDaemonSetNamespace("ns"),
+				teststubappsv1.WithDaemonSetUpdateStrategy(appsv1.RollingUpdateDaemonSetStrategyType, nil),
+				teststubappsv1.WithDaemonSetStatus(int64(3), int64(3), int32(3), int32(1), int32(3)))),
+			daemonSetPtr(teststubappsv1.ConstructExpectedDaemonSet(
+				teststubappsv1.WithDaemonSetNamespace("ns"),
+				teststubappsv1.WithDaemonSetUpdateStrategy(appsv1.RollingUpdateDaemonSetStrategyType, nil),
+				teststubappsv1.WithDaemonSetStatus(int64(3), int64(3), int32(3), int32(3), int32(2)))),
+			daemonSetPtr(teststubappsv1.ConstructExpectedDaemonSet(
+				teststubappsv1.WithDaemonSetNamespace("ns"),
+				teststubappsv1.WithDaemonSetUpdateStrategy(appsv1.RollingUpdateDaemonSetStrategyType, nil),
+				teststubappsv1.WithDaemonSetStatus(int64(3), int64(3), int32(3), int32(3), int32(3)))),
+		},
+		wantProgress: []string{
+			`waiting for daemon set "foo" spec update to be observed`,
+			"1 out of 3 new pods have been updated",
+			"2 of 3 updated pods are available",
+			`daemon set "foo" successfully rolled out`,
+		},
+	}, {
+		name: "unknown kind",
+		kind: RolloutKind("ReplicaSet"),
+		initial: daemonSetPtr(teststubappsv1.ConstructExpectedDaemonSet(
+			teststubappsv1.WithDaemonSetNamespace("ns"))),
+		wantErr: "rollout status is not supported for kind ReplicaSet",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := testclient.NewSimpleClientset(tc.initial)
+			fw := watch.NewFake()
+			cs.PrependWatchReactor("*", func(action clienttesting.Action) (bool, watch.Interface, error) {
+				return true, fw, nil
+			})
+			events := tc.events
+			go func() {
+				for i := range events {
+					fw.Modify(events[i])
+				}
+			}()
+			c := &appsClient{tappsv1: cs.AppsV1(), ctx: context.Background()}
+			var progress []string
+			act, err := c.WaitForRollout("ns", tc.kind, "foo", time.Minute, func(p RolloutProgress) {
+				progress = append(progress, p.Message)
+			})
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, act.Done)
+			assert.DeepEqual(t, tc.wantProgress, progress)
+		})
+	}
+}
+
+func TestWaitForRolloutTimeout(t *testing.T) {
+	deployment := teststubappsv1.ConstructExpectedDeployment(
+		teststubappsv1.WithNamespace("ns"),
+		teststubappsv1.WithReplicas(int32(2)),
+		teststubappsv1.WithReplicaStatus(int32(2), int32(2), int32(2), int32(1)))
+	cs := testclient.NewSimpleClientset(&deployment)
+	cs.PrependWatchReactor("deployments", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		return true, watch.NewFake(), nil
+	})
+	c := &appsClient{tappsv1: cs.AppsV1(), ctx: context.Background()}
+	_, err := c.WaitForRollout("ns", DeploymentKind, "foo", 50*time.Millisecond, nil)
+	timeoutErr, ok := err.(*RolloutTimeoutError)
+	assert.Assert(t, ok)
+	assert.Equal(t, timeoutErr.Last.Available, int32(1))
+	assert.Error(t, err, "rollout of Deployment foo did not finish in 50ms: 1 of 2 updated replicas are available")
+}
+
+func TestWaitForRolloutDeleted(t *testing.T) {
+	deployment := teststubappsv1.ConstructExpectedDeployment(
+		teststubappsv1.WithNamespace("ns"),
+		teststubappsv1.WithReplicas(int32(2)),
+		teststubappsv1.WithReplicaStatus(int32(2), int32(2), int32(2), int32(1)))
+	cs := testclient.NewSimpleClientset(&deployment)
+	fw := watch.NewFake()
+	cs.PrependWatchReactor("deployments", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		return true, fw, nil
+	})
+	go fw.Delete(&deployment)
+	c := &appsClient{tappsv1: cs.AppsV1(), ctx: context.Background()}
+	_, err := c.WaitForRollout("ns", DeploymentKind, "foo", 5*time.Second, nil)
+	assert.Error(t, err, "Deployment foo was deleted during the rollout")
+}
+
+func statefulSetPtr(statefulSet appsv1.StatefulSet) *appsv1.StatefulSet {
+	return &statefulSet
+}
+
+func daemonSetPtr(daemonSet appsv1.DaemonSet) *appsv1.DaemonSet {
+	return &daemonSet
+}
@@ -0,0 +1,138 @@
+package appsv1
+
+import (
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/ptr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/corev1"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
+)
+
+const (
+	//INVALID_STORAGE_SIZE - error message to indicate the claim size is not a quantity
+	INVALID_STORAGE_SIZE = "invalid storage size %q for volume claim %s: %v"
+)
+
+type StatefulSetOption func(*appsv1.StatefulSet)
+
+//GetStatefulSet construct stateful set spec based on option provided
+func GetStatefulSet(name string, options ...StatefulSetOption) appsv1.StatefulSet {
+	statefulSet := appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&statefulSet)
+	}
+	return statefulSet
+}
+
+func WithStatefulSetPodSpecOptions(podSpec kubernetes.PodSpec, options ...corev1.PodSpecOption) StatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.Template.Spec = corev1.GetPodSpec(podSpec, options...)
+	}
+}
+
+func WithStatefulSetReplicas(replicas int32) StatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		if replicas >= int32(0) {
+			statefulSet.Spec.Replicas = ptr.Int32(replicas)
+		}
+	}
+}
+
+//WithStatefulSetLabels - pod template labels, the selector of the stateful set matches them
+func WithStatefulSetLabels(inLabels []kubernetes.KV) StatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		labels := transform.GetStringMap(inLabels, nil)
+		if len(labels) == 0 {
+			return
+		}
+		statefulSet.Spec.Template.ObjectMeta.Labels = labels
+		statefulSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: transform.GetStringMap(inLabels, nil)}
+	}
+}
+
+//WithServiceName - headless service giving the pods their stable network identity
+func WithServiceName(serviceName string) StatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		if serviceName != "" {
+			statefulSet.Spec.ServiceName = serviceName
+		}
+	}
+}
+
+//WithPodManagementPolicy - appsv1.OrderedReadyPodManagement or appsv1.ParallelPodManagement
+func WithPodManagementPolicy(policy appsv1.PodManagementPolicyType) StatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		if policy != "" {
+			statefulSet.Spec.PodManagementPolicy = policy
+		}
+	}
+}
+
+//WithStatefulSetRollingUpdate - pods with an ordinal at or above the partition are updated,
+//0 updates all pods
+func WithStatefulSetRollingUpdate(partition int32) StatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		}
+		if partition > int32(0) {
+			statefulSet.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{
+				Partition: ptr.Int32(partition),
+			}
+		}
+	}
+}
+
+//WithStatefulSetOnDelete - pods are only updated when they are deleted
+func WithStatefulSetOnDelete() StatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{
+			Type: appsv1.OnDeleteStatefulSetStrategyType,
+		}
+	}
+}
+
+//WithVolumeClaimTemplate - claim created for every pod, mount it with the claim name.
+//size is a quantity like 10Gi, empty storage class uses the cluster default and
+//access modes default to ReadWriteOnce
+func WithVolumeClaimTemplate(name, size, storageClass string, accessModes ...corev1api.PersistentVolumeAccessMode) (StatefulSetOption, error) {
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return nil, fmt.Errorf(INVALID_STORAGE_SIZE, size, name, err)
+	}
+	if len(accessModes) == 0 {
+		accessModes = []corev1api.PersistentVolumeAccessMode{corev1api.ReadWriteOnce}
+	}
+	claim := corev1api.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: corev1api.PersistentVolumeClaimSpec{
+			AccessModes: accessModes,
+			Resources: corev1api.ResourceRequirements{
+				Requests: corev1api.ResourceList{corev1api.ResourceStorage: quantity},
+			},
+		},
+	}
+	if storageClass != "" {
+		claim.Spec.StorageClassName = ptr.String(storageClass)
+	}
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.VolumeClaimTemplates = append(statefulSet.Spec.VolumeClaimTemplates, claim)
+	}, nil
+}
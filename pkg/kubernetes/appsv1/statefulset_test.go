@@ -0,0 +1,76 @@
+package appsv1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1api "k8s.io/api/core/v1"
+
+	"knative.dev/pkg/ptr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	corev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/corev1"
+	teststubappsv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/appsv1"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+func TestGetStatefulSet(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		want         appsv1.StatefulSet
+		inputOptions []StatefulSetOption
+	}{{
+		name: "stateful set with all options",
+		want: teststubappsv1.ConstructExpectedStatefulSet(
+			teststubappsv1.WithStatefulSetPodSpecOptions(
+				teststubcorev1.WithContainerOptions(teststubcorev1.WithImage("db"))),
+			teststubappsv1.WithStatefulSetReplicas(int32(3)),
+			teststubappsv1.WithStatefulSetLabels(map[string]string{"app": "db"}),
+			teststubappsv1.WithServiceName("db-headless"),
+			teststubappsv1.WithPodManagementPolicy(appsv1.ParallelPodManagement),
+			teststubappsv1.WithStatefulSetUpdateStrategy(appsv1.RollingUpdateStatefulSetStrategyType, ptr.Int32(2)),
+			teststubappsv1.WithVolumeClaimTemplate("data", "10Gi", ptr.String("fast"), corev1api.ReadWriteOnce),
+			teststubappsv1.WithVolumeClaimTemplate("shared", "1Gi", nil, corev1api.ReadWriteMany)),
+		inputOptions: []StatefulSetOption{
+			WithStatefulSetPodSpecOptions(kubernetes.PodSpec{},
+				corev1.WithContainerOptions(kubernetes.ContainerSpec{Image: "db"})),
+			WithStatefulSetReplicas(int32(3)),
+			WithStatefulSetLabels([]kubernetes.KV{{"app", "db"}}),
+			WithServiceName("db-headless"),
+			WithPodManagementPolicy(appsv1.ParallelPodManagement),
+			WithStatefulSetRollingUpdate(int32(2)),
+			mustClaim(t, "data", "10Gi", "fast"),
+			mustClaim(t, "shared", "1Gi", "", corev1api.ReadWriteMany),
+		},
+	}, {
+		name: "stateful set with null options",
+		want: teststubappsv1.ConstructExpectedStatefulSet(
+			teststubappsv1.WithStatefulSetUpdateStrategy(appsv1.OnDeleteStatefulSetStrategyType, nil)),
+		inputOptions: []StatefulSetOption{
+			WithStatefulSetReplicas(int32(-1)),
+			WithStatefulSetLabels(nil),
+			WithServiceName(""),
+			WithPodManagementPolicy(""),
+			WithStatefulSetRollingUpdate(int32(0)),
+			WithStatefulSetOnDelete(),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			act := GetStatefulSet("foo", tc.inputOptions...)
+			assert.DeepEqual(t, &tc.want, &act)
+		})
+	}
+}
+
+func TestWithVolumeClaimTemplateInvalidSize(t *testing.T) {
+	_, err := WithVolumeClaimTemplate("data", "ten gigs", "")
+	assert.ErrorContains(t, err, `invalid storage size "ten gigs" for volume claim data`)
+}
+
+func mustClaim(t *testing.T, name, size, storageClass string, accessModes ...corev1api.PersistentVolumeAccessMode) StatefulSetOption {
+	opt, err := WithVolumeClaimTemplate(name, size, storageClass, accessModes...)
+	assert.NilError(t, err)
+	return opt
+}
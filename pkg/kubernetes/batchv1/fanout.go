@@ -0,0 +1,124 @@
+package batchv1
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+)
+
+const (
+	//FAN_OUT_FAILED - error message to indicate some of the jobs of the fan out failed
+	FAN_OUT_FAILED = "%d of %d jobs failed: %s"
+)
+
+//JobResult - outcome of one job started by RunJobs
+type JobResult struct {
+	Name   string
+	Params []kubernetes.KV
+	//Job is the last seen state, nil when the job could not be created
+	Job *batchv1.Job
+	Err error
+}
+
+//FanOutError - aggregates the failed jobs of RunJobs
+type FanOutError struct {
+	Failed []JobResult
+	Total  int
+}
+
+func (e *FanOutError) Error() string {
+	var msgs []string
+	for _, result := range e.Failed {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", result.Name, result.Err))
+	}
+	return fmt.Sprintf(FAN_OUT_FAILED, len(e.Failed), e.Total, strings.Join(msgs, "; "))
+}
+
+//RunJobs creates a job per parameter set from the base job, the parameters are added
+//as env variables to every container. Jobs are named <base name>-<run>-<index>, run is
+//random per call so the same base can be run again. At most
+//maxInFlight of them run at a time, 0 runs all at once. Every job is waited on,
+//results are in the order of params and failures are returned as *FanOutError.
+//Cancelling the client context stops starting new jobs and the wait on the running ones
+func (c *batchClient) RunJobs(ns string, base batchv1.Job, params [][]kubernetes.KV, maxInFlight int) ([]JobResult, error) {
+	if maxInFlight <= 0 {
+		maxInFlight = len(params)
+	}
+	results := make([]JobResult, len(params))
+	sem := make(chan struct{}, maxInFlight)
+	run := utilrand.String(5)
+	var wg sync.WaitGroup
+
+	for i, kvs := range params {
+		results[i] = JobResult{Name: fanOutJobName(base.Name, run, i), Params: kvs}
+		if err := c.ctx.Err(); err != nil {
+			results[i].Err = err
+			continue
+		}
+		select {
+		case <-c.ctx.Done():
+			results[i].Err = c.ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+		wg.Add(1)
+		go func(result *JobResult) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			job := fanOutJob(base, result.Name, result.Params)
+			result.Job, result.Err = c.CreateJob(ns, job, true)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	var failed []JobResult
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	if len(failed) > 0 {
+		return results, &FanOutError{Failed: failed, Total: len(params)}
+	}
+	return results, nil
+}
+
+//fanOutJobName keeps the name within the 63 characters allowed for the job-name label
+func fanOutJobName(base, run string, index int) string {
+	suffix := fmt.Sprintf("-%s-%d", run, index)
+	if len(base)+len(suffix) > 63 {
+		base = strings.TrimRight(base[:63-len(suffix)], "-")
+	}
+	return base + suffix
+}
+
+//fanOutJob copies the base job with the name and the parameters as env,
+//parameters replace env variables of the same name
+func fanOutJob(base batchv1.Job, name string, params []kubernetes.KV) *batchv1.Job {
+	job := base.DeepCopy()
+	job.Name = name
+	job.ResourceVersion = ""
+	for i := range job.Spec.Template.Spec.Containers {
+		container := &job.Spec.Template.Spec.Containers[i]
+		for _, kv := range params {
+			container.Env = setEnv(container.Env, corev1.EnvVar{Name: kv.Key, Value: kv.Value})
+		}
+	}
+	return job
+}
+
+func setEnv(envs []corev1.EnvVar, env corev1.EnvVar) []corev1.EnvVar {
+	for i := range envs {
+		if envs[i].Name == env.Name {
+			envs[i] = env
+			return envs
+		}
+	}
+	return append(envs, env)
+}
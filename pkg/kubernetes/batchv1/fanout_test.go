@@ -0,0 +1,157 @@
+package batchv1
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	corev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/corev1"
+)
+
+//finishOnCreate completes the created jobs, jobs with SHARD=fail are failed
+func finishOnCreate(cs *testclient.Clientset, delay time.Duration) func() int {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	cs.PrependReactor("create", "jobs", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		time.Sleep(delay)
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		job := action.(clienttesting.CreateAction).GetObject().(*batchv1.Job)
+		cond := batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1api.ConditionTrue}
+		for _, env := range job.Spec.Template.Spec.Containers[0].Env {
+			if env.Name == "SHARD" && env.Value == "fail" {
+				cond = batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1api.ConditionTrue, Reason: "BackoffLimitExceeded"}
+			}
+		}
+		job.Status.Conditions = append(job.Status.Conditions, cond)
+		return false, nil, nil
+	})
+	cs.PrependWatchReactor("jobs", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		return true, watch.NewFake(), nil
+	})
+	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return maxInFlight
+	}
+}
+
+func fanOutBase() batchv1.Job {
+	return GetJob("shard",
+		WithPodSpecOptions(kubernetes.PodSpec{},
+			corev1.WithContainerOptions(kubernetes.ContainerSpec{Image: "worker"},
+				corev1.WithEnv([]corev1api.EnvVar{{Name: "SHARD", Value: "base"}, {Name: "MODE", Value: "full"}}))))
+}
+
+func TestRunJobs(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		params      [][]kubernetes.KV
+		maxInFlight int
+		wantNames   []string
+		wantFailed  []string
+	}{{
+		name:        "all jobs complete",
+		params:      [][]kubernetes.KV{{{"SHARD", "0"}}, {{"SHARD", "1"}}, {{"SHARD", "2"}}, {{"SHARD", "3"}}},
+		maxInFlight: 2,
+		wantNames:   []string{"0", "1", "2", "3"},
+	}, {
+		name:        "failures aggregated",
+		params:      [][]kubernetes.KV{{{"SHARD", "0"}}, {{"SHARD", "fail"}}, {{"SHARD", "2"}}},
+		maxInFlight: 0,
+		wantNames:   []string{"0", "1", "2"},
+		wantFailed:  []string{"1"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := testclient.NewSimpleClientset()
+			maxInFlight := finishOnCreate(cs, 20*time.Millisecond)
+			c := &batchClient{tbatchv1: cs.BatchV1(), ctx: context.Background()}
+			results, err := c.RunJobs("ns", fanOutBase(), tc.params, tc.maxInFlight)
+
+			//names are shard-<run>-<index> with the same run for all the jobs
+			run := strings.Split(results[0].Name, "-")[1]
+			assert.Equal(t, len(run), 5)
+			var names []string
+			for i, result := range results {
+				assert.Assert(t, strings.HasPrefix(result.Name, "shard-"+run+"-"))
+				names = append(names, strings.TrimPrefix(result.Name, "shard-"+run+"-"))
+				assert.DeepEqual(t, tc.params[i], result.Params)
+				assert.Assert(t, result.Job != nil)
+				assert.DeepEqual(t, result.Job.Spec.Template.Spec.Containers[0].Env, []corev1api.EnvVar{
+					{Name: "SHARD", Value: tc.params[i][0].Value},
+					{Name: "MODE", Value: "full"},
+				})
+			}
+			assert.DeepEqual(t, tc.wantNames, names)
+			if tc.maxInFlight > 0 {
+				assert.Assert(t, maxInFlight() <= tc.maxInFlight)
+			}
+
+			if len(tc.wantFailed) == 0 {
+				assert.NilError(t, err)
+				return
+			}
+			fanOutErr, ok := err.(*FanOutError)
+			assert.Assert(t, ok)
+			assert.Equal(t, fanOutErr.Total, len(tc.params))
+			var failed []string
+			for _, result := range fanOutErr.Failed {
+				failed = append(failed, strings.TrimPrefix(result.Name, "shard-"+run+"-"))
+				_, ok := result.Err.(*JobFailedError)
+				assert.Assert(t, ok)
+			}
+			assert.DeepEqual(t, tc.wantFailed, failed)
+		})
+	}
+}
+
+func TestRunJobsCancelled(t *testing.T) {
+	cs := testclient.NewSimpleClientset()
+	finishOnCreate(cs, 0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c := &batchClient{tbatchv1: cs.BatchV1(), ctx: ctx}
+	results, err := c.RunJobs("ns", fanOutBase(), [][]kubernetes.KV{{{"SHARD", "0"}}, {{"SHARD", "1"}}}, 1)
+	assert.ErrorContains(t, err, "2 of 2 jobs failed")
+	for _, result := range results {
+		assert.Equal(t, context.Canceled, result.Err)
+	}
+}
+
+func TestRunJobsTwice(t *testing.T) {
+	cs := testclient.NewSimpleClientset()
+	finishOnCreate(cs, 0)
+	c := &batchClient{tbatchv1: cs.BatchV1(), ctx: context.Background()}
+	params := [][]kubernetes.KV{{{"SHARD", "0"}}, {{"SHARD", "1"}}}
+	_, err := c.RunJobs("ns", fanOutBase(), params, 0)
+	assert.NilError(t, err)
+	_, err = c.RunJobs("ns", fanOutBase(), params, 0)
+	assert.NilError(t, err)
+}
+
+func TestFanOutJobName(t *testing.T) {
+	assert.Equal(t, fanOutJobName("shard", "abcde", 3), "shard-abcde-3")
+	long := strings.Repeat("a", 53) + "-" + strings.Repeat("b", 9)
+	name := fanOutJobName(long, "abcde", 12)
+	assert.Equal(t, name, strings.Repeat("a", 53)+"-abcde-12")
+}
@@ -6,6 +6,7 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	typedbatchv1 "k8s.io/client-go/kubernetes/typed/batch/v1"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
 
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/ptr"
@@ -19,6 +20,7 @@ type JobSpecOption func(*batchv1.Job)
 
 type batchClient struct {
 	tbatchv1 typedbatchv1.BatchV1Interface
+	tcorev1  typedcorev1.CoreV1Interface
 	ctx      context.Context
 }
 
@@ -26,12 +28,19 @@ func Client(c context.Context) *batchClient {
 	cs := kubernetes.KubernetesCSFromContext(c)
 	return &batchClient{
 		tbatchv1: cs.BatchV1(),
+		tcorev1:  cs.CoreV1(),
 		ctx:      c,
 	}
 }
 
+//CreateJob creates the job, with watch the call waits
+//till the job completes or fails, see WaitForJob
 func (c *batchClient) CreateJob(ns string, job *batchv1.Job, watch bool) (*batchv1.Job, error) {
-	return c.tbatchv1.Jobs(ns).Create(c.ctx, job, metav1.CreateOptions{})
+	created, err := c.tbatchv1.Jobs(ns).Create(c.ctx, job, metav1.CreateOptions{})
+	if err != nil || !watch {
+		return created, err
+	}
+	return c.WaitForJob(ns, created.Name)
 }
 
 func (c *batchClient) GetJobStatus(ns, jobName string) (*batchv1.JobStatus, error) {
@@ -104,3 +113,35 @@ func WithOwnerReference(obj kmeta.OwnerRefable) JobSpecOption {
 		job.ObjectMeta.OwnerReferences = []metav1.OwnerReference{*ownerRef}
 	}
 }
+
+//WithCompletions - number of pods which have to succeed. The Indexed completion mode is not
+//available, it needs batch/v1 of k8s 1.21 and the knative version used here builds with 1.18
+func WithCompletions(completions int32) JobSpecOption {
+	return func(job *batchv1.Job) {
+		if completions > int32(0) {
+			job.Spec.Completions = ptr.Int32(completions)
+		}
+	}
+}
+
+func WithActiveDeadline(seconds int64) JobSpecOption {
+	return func(job *batchv1.Job) {
+		if seconds > int64(0) {
+			job.Spec.ActiveDeadlineSeconds = ptr.Int64(seconds)
+		}
+	}
+}
+
+//WithManualSelector - selector for the job pods instead of the generated controller-uid,
+//the labels are added to the pod template so use it after WithLabels
+func WithManualSelector(selector []kubernetes.KV) JobSpecOption {
+	return func(job *batchv1.Job) {
+		matchLabels := transform.GetStringMap(selector, nil)
+		if len(matchLabels) == 0 {
+			return
+		}
+		job.Spec.ManualSelector = ptr.Bool(true)
+		job.Spec.Selector = &metav1.LabelSelector{MatchLabels: matchLabels}
+		job.Spec.Template.ObjectMeta.Labels = transform.GetStringMap(selector, job.Spec.Template.ObjectMeta.Labels)
+	}
+}
@@ -56,6 +56,46 @@ func TestGetPodSpec(t *testing.T) {
 				corev1.WithServiceAccount("admin-sa"),
 				corev1.WithRestartPolicy("Never")),
 		},
+	}, {
+		name: "Job with completion options",
+		wantJob: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithPodSpecOptions(
+				teststubcorev1.WithContainerOptions(
+					teststubcorev1.WithImage("shard")),
+				teststubcorev1.WithContainerOptions(
+					teststubcorev1.WithImage("sidecar")),
+				teststubcorev1.WithRestartPolicy("Never")),
+			teststubbatchv1.WithLabels(map[string]string{"app": "shard", "shard-set": "a"}),
+			teststubbatchv1.WithManualSelector(map[string]string{"shard-set": "a"}),
+			teststubbatchv1.WithCompletions(int32(10)),
+			teststubbatchv1.WithActiveDeadline(int64(600)),
+		),
+		inputName: "foo",
+		inputOptions: []JobSpecOption{
+			WithPodSpecOptions(kubernetes.PodSpec{},
+				corev1.WithContainerOptions(kubernetes.ContainerSpec{Image: "shard"}),
+				corev1.WithContainerOptions(kubernetes.ContainerSpec{Image: "sidecar"}),
+				corev1.WithRestartPolicy("Never")),
+			WithLabels([]kubernetes.KV{{"app", "shard"}}),
+			WithManualSelector([]kubernetes.KV{{"shard-set", "a"}}),
+			WithCompletions(int32(10)),
+			WithActiveDeadline(int64(600)),
+		},
+	}, {
+		name: "Completion options with null values",
+		wantJob: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithPodSpecOptions(
+				teststubcorev1.WithContainerOptions(
+					teststubcorev1.WithImage("shard"))),
+		),
+		inputName: "foo",
+		inputOptions: []JobSpecOption{
+			WithPodSpecOptions(kubernetes.PodSpec{},
+				corev1.WithContainerOptions(kubernetes.ContainerSpec{Image: "shard"})),
+			WithManualSelector(nil),
+			WithCompletions(int32(0)),
+			WithActiveDeadline(int64(0)),
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			actJob := GetJob(tc.inputName, tc.inputOptions...)
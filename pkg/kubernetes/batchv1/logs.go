@@ -0,0 +1,190 @@
+package batchv1
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+//logPollInterval - how often new pods of the job are looked up while streaming
+var logPollInterval = 2 * time.Second
+
+//GetJobPods returns the pods created for the job, using the controller-uid
+//selector of the job and falling back to the job-name label
+func (c *batchClient) GetJobPods(ns, jobName string) ([]corev1.Pod, error) {
+	job, err := c.tbatchv1.Jobs(ns).Get(c.ctx, jobName, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return c.listJobPods(job)
+}
+
+func (c *batchClient) listJobPods(job *batchv1.Job) ([]corev1.Pod, error) {
+	selector := labels.SelectorFromSet(labels.Set{"job-name": job.Name})
+	if job.Spec.Selector != nil {
+		jobSelector, err := metav1.LabelSelectorAsSelector(job.Spec.Selector)
+		if err != nil {
+			return nil, err
+		}
+		selector = jobSelector
+	}
+	podList, err := c.tcorev1.Pods(job.Namespace).List(c.ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
+//StreamJobLogs follows the logs of every init container and container of the job pods till the
+//job finishes. Each line is prefixed with [pod/container], pods created for retries are picked up
+//as they start. A failed job returns *JobFailedError. Nothing is written to out after it returns
+func (c *batchClient) StreamJobLogs(ns, jobName string, out io.Writer) error {
+	w := &prefixWriter{out: out}
+	streamed := make(map[string]bool)
+	errs := make(chan error, 1)
+	ctx, cancel := context.WithCancel(c.ctx)
+	var wg sync.WaitGroup
+	//an early return stops the streams and waits for them
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	startStreams := func(job *batchv1.Job) error {
+		pods, err := c.listJobPods(job)
+		if err != nil {
+			return err
+		}
+		for _, pod := range pods {
+			for _, container := range startedContainers(pod) {
+				key := pod.Name + "/" + container
+				if streamed[key] {
+					continue
+				}
+				streamed[key] = true
+				wg.Add(1)
+				go func(pod, container string) {
+					defer wg.Done()
+					if err := c.streamContainerLogs(ctx, ns, pod, container, w); err != nil {
+						select {
+						case errs <- err:
+						default:
+						}
+					}
+				}(pod.Name, container)
+			}
+		}
+		return nil
+	}
+
+	var jobErr error
+	for {
+		job, err := c.tbatchv1.Jobs(ns).Get(c.ctx, jobName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if err := startStreams(job); err != nil {
+			return err
+		}
+		done, err := jobFinished(job)
+		if done {
+			jobErr = err
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(logPollInterval):
+		}
+	}
+	wg.Wait()
+
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return jobErr
+	}
+}
+
+//GetJobLogs waits for the job to finish and returns the logs keyed by pod/container.
+//A failed job returns the collected logs along with *JobFailedError
+func (c *batchClient) GetJobLogs(ns, jobName string) (map[string]string, error) {
+	job, jobErr := c.WaitForJob(ns, jobName)
+	if _, ok := jobErr.(*JobFailedError); jobErr != nil && !ok {
+		return nil, jobErr
+	}
+	pods, err := c.listJobPods(job)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make(map[string]string)
+	for _, pod := range pods {
+		for _, container := range startedContainers(pod) {
+			stream, err := c.tcorev1.Pods(ns).GetLogs(pod.Name, &corev1.PodLogOptions{Container: container}).Stream(c.ctx)
+			if err != nil {
+				return logs, err
+			}
+			data, err := ioutil.ReadAll(stream)
+			stream.Close()
+			if err != nil {
+				return logs, err
+			}
+			logs[pod.Name+"/"+container] = string(data)
+		}
+	}
+	return logs, jobErr
+}
+
+func (c *batchClient) streamContainerLogs(ctx context.Context, ns, pod, container string, w *prefixWriter) error {
+	stream, err := c.tcorev1.Pods(ns).GetLogs(pod, &corev1.PodLogOptions{Container: container, Follow: true}).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	prefix := fmt.Sprintf("[%s/%s] ", pod, container)
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		if err := w.writeLine(prefix, scanner.Text()); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+//startedContainers returns the init containers and containers of the pod which are running or terminated
+func startedContainers(pod corev1.Pod) []string {
+	var containers []string
+	statuses := append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Running != nil || status.State.Terminated != nil {
+			containers = append(containers, status.Name)
+		}
+	}
+	return containers
+}
+
+//prefixWriter serializes the lines from the container streams
+type prefixWriter struct {
+	mu  sync.Mutex
+	out io.Writer
+}
+
+func (w *prefixWriter) writeLine(prefix, line string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err := fmt.Fprintf(w.out, "%s%s\n", prefix, line)
+	return err
+}
@@ -0,0 +1,169 @@
+package batchv1
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	restclient "k8s.io/client-go/rest"
+	fakerest "k8s.io/client-go/rest/fake"
+
+	teststubbatchv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/batchv1"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+//fakeLogsCoreV1 serves pod logs from a map keyed by pod/container,
+//the fake clientset does not implement GetLogs. With follow set the
+//streams stay open till the request is cancelled and are counted in closed
+type fakeLogsCoreV1 struct {
+	typedcorev1.CoreV1Interface
+	logs   map[string]string
+	follow bool
+	closed *int32
+}
+
+func (f fakeLogsCoreV1) Pods(ns string) typedcorev1.PodInterface {
+	return fakeLogsPods{f.CoreV1Interface.Pods(ns), f}
+}
+
+type fakeLogsPods struct {
+	typedcorev1.PodInterface
+	f fakeLogsCoreV1
+}
+
+func (p fakeLogsPods) GetLogs(name string, opts *corev1.PodLogOptions) *restclient.Request {
+	body := p.f.logs[name+"/"+opts.Container]
+	client := &fakerest.RESTClient{
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		Client: fakerest.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			if !p.f.follow {
+				return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(body))}, nil
+			}
+			r, w := io.Pipe()
+			go func() {
+				io.WriteString(w, body)
+				<-req.Context().Done()
+				w.CloseWithError(req.Context().Err())
+			}()
+			return &http.Response{StatusCode: http.StatusOK, Body: countingCloser{r, p.f.closed}}, nil
+		}),
+	}
+	return client.Get()
+}
+
+type countingCloser struct {
+	io.ReadCloser
+	closed *int32
+}
+
+func (c countingCloser) Close() error {
+	atomic.AddInt32(c.closed, 1)
+	return c.ReadCloser.Close()
+}
+
+func jobLogObjects(job batchv1.Job) []runtime.Object {
+	return []runtime.Object{
+		&job,
+		teststubcorev1.ConstructPod("ns", "foo-1",
+			teststubcorev1.WithPodLabels(map[string]string{"job-name": "foo"}),
+			teststubcorev1.WithTerminatedInitContainer("migrate", int32(0)),
+			teststubcorev1.WithTerminatedContainer("main", int32(1), ""),
+			teststubcorev1.WithTerminatedContainer("sidecar", int32(0), "")),
+		teststubcorev1.ConstructPod("ns", "foo-2",
+			teststubcorev1.WithPodLabels(map[string]string{"job-name": "foo"}),
+			teststubcorev1.WithTerminatedContainer("main", int32(0), ""),
+			teststubcorev1.WithWaitingContainer("sidecar", "PodInitializing")),
+		teststubcorev1.ConstructPod("ns", "bar-1",
+			teststubcorev1.WithPodLabels(map[string]string{"job-name": "bar"}),
+			teststubcorev1.WithTerminatedContainer("main", int32(0), "")),
+	}
+}
+
+var jobLogs = map[string]string{
+	"foo-1/migrate": "migrated",
+	"foo-1/main":    "starting\nfailed\n",
+	"foo-1/sidecar": "shipping",
+	"foo-2/main":    "starting\ndone\n",
+	"bar-1/main":    "bar",
+}
+
+func TestStreamJobLogs(t *testing.T) {
+	logPollInterval = 10 * time.Millisecond
+	cs := testclient.NewSimpleClientset(jobLogObjects(teststubbatchv1.ConstructExpectedJobSpec(
+		teststubbatchv1.WithNamespace("ns"),
+		teststubbatchv1.WithCondition(batchv1.JobComplete, "", "")))...)
+	c := &batchClient{
+		tbatchv1: cs.BatchV1(),
+		tcorev1:  fakeLogsCoreV1{CoreV1Interface: cs.CoreV1(), logs: jobLogs},
+		ctx:      context.Background(),
+	}
+	var out bytes.Buffer
+	assert.NilError(t, c.StreamJobLogs("ns", "foo", &out))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	sort.Strings(lines)
+	assert.DeepEqual(t, []string{
+		"[foo-1/main] failed",
+		"[foo-1/main] starting",
+		"[foo-1/migrate] migrated",
+		"[foo-1/sidecar] shipping",
+		"[foo-2/main] done",
+		"[foo-2/main] starting",
+	}, lines)
+}
+
+func TestStreamJobLogsCancelled(t *testing.T) {
+	logPollInterval = 10 * time.Millisecond
+	job := teststubbatchv1.ConstructExpectedJobSpec(teststubbatchv1.WithNamespace("ns"))
+	cs := testclient.NewSimpleClientset(&job,
+		teststubcorev1.ConstructPod("ns", "foo-1",
+			teststubcorev1.WithPodLabels(map[string]string{"job-name": "foo"}),
+			teststubcorev1.WithRunningContainer("main")))
+	var closed int32
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	c := &batchClient{
+		tbatchv1: cs.BatchV1(),
+		tcorev1:  fakeLogsCoreV1{CoreV1Interface: cs.CoreV1(), logs: map[string]string{"foo-1/main": "running\n"}, follow: true, closed: &closed},
+		ctx:      ctx,
+	}
+	var out bytes.Buffer
+	assert.Equal(t, context.DeadlineExceeded, c.StreamJobLogs("ns", "foo", &out))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&closed))
+	assert.Equal(t, "[foo-1/main] running\n", out.String())
+}
+
+func TestGetJobLogs(t *testing.T) {
+	cs := testclient.NewSimpleClientset(jobLogObjects(teststubbatchv1.ConstructExpectedJobSpec(
+		teststubbatchv1.WithNamespace("ns"),
+		teststubbatchv1.WithPodCounts(int32(0), int32(1), int32(1)),
+		teststubbatchv1.WithCondition(batchv1.JobFailed, "BackoffLimitExceeded", "")))...)
+	c := &batchClient{
+		tbatchv1: cs.BatchV1(),
+		tcorev1:  fakeLogsCoreV1{CoreV1Interface: cs.CoreV1(), logs: jobLogs},
+		ctx:      context.Background(),
+	}
+	logs, err := c.GetJobLogs("ns", "foo")
+	assert.Error(t, err, (&JobFailedError{Name: "foo", Reason: "BackoffLimitExceeded", Failed: int32(1)}).Error())
+	assert.DeepEqual(t, map[string]string{
+		"foo-1/migrate": "migrated",
+		"foo-1/main":    "starting\nfailed\n",
+		"foo-1/sidecar": "shipping",
+		"foo-2/main":    "starting\ndone\n",
+	}, logs)
+}
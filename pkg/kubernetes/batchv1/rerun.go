@@ -0,0 +1,84 @@
+package batchv1
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	//JOB_NOT_FINISHED - error message to indicate the job is still running
+	JOB_NOT_FINISHED = "job %s has not finished, only complete or failed jobs can be rerun"
+)
+
+//labels the job controller adds to the job and its pod template
+var controllerLabels = []string{"controller-uid", "job-name"}
+
+//RerunJob creates a new job from the spec of the finished job. The generated selector and
+//the controller-uid and job-name labels are removed so the controller sets them for the
+//new job, a manual selector is kept. The new job is named <name>-rerun-<random suffix>
+func (c *batchClient) RerunJob(ns, name string) (*batchv1.Job, error) {
+	job, err := c.tbatchv1.Jobs(ns).Get(c.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if done, _ := jobFinished(job); !done {
+		return nil, fmt.Errorf(JOB_NOT_FINISHED, name)
+	}
+	return c.tbatchv1.Jobs(ns).Create(c.ctx, rerunJob(job), metav1.CreateOptions{})
+}
+
+//DeleteJob deletes the job, with an empty policy metav1.DeletePropagationBackground
+//is used so the pods are removed along with the job instead of being orphaned
+func (c *batchClient) DeleteJob(ns, name string, policy metav1.DeletionPropagation) error {
+	if policy == "" {
+		policy = metav1.DeletePropagationBackground
+	}
+	return c.tbatchv1.Jobs(ns).Delete(c.ctx, name, metav1.DeleteOptions{PropagationPolicy: &policy})
+}
+
+func rerunJob(job *batchv1.Job) *batchv1.Job {
+	suffix := "-rerun-" + utilrand.String(5)
+	prefix := job.Name
+	if len(prefix)+len(suffix) > 63 {
+		prefix = prefix[:63-len(suffix)]
+	}
+	rerun := &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        prefix + suffix,
+			Namespace:   job.Namespace,
+			Labels:      withoutControllerLabels(job.Labels),
+			Annotations: job.Annotations,
+		},
+		Spec: *job.Spec.DeepCopy(),
+	}
+	if rerun.Spec.ManualSelector == nil || !*rerun.Spec.ManualSelector {
+		rerun.Spec.Selector = nil
+		rerun.Spec.ManualSelector = nil
+	}
+	rerun.Spec.Template.ObjectMeta.Labels = withoutControllerLabels(rerun.Spec.Template.ObjectMeta.Labels)
+	return rerun
+}
+
+func withoutControllerLabels(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return labels
+	}
+	stripped := make(map[string]string, len(labels))
+	for k, v := range labels {
+		stripped[k] = v
+	}
+	for _, k := range controllerLabels {
+		delete(stripped, k)
+	}
+	if len(stripped) == 0 {
+		return nil
+	}
+	return stripped
+}
@@ -0,0 +1,79 @@
+package batchv1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	batchv1 "k8s.io/api/batch/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	teststubbatchv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/batchv1"
+)
+
+func TestRerunJob(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		job     batchv1.Job
+		want    batchv1.Job
+		wantErr string
+	}{{
+		name: "generated selector and labels removed",
+		job: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithNamespace("ns"),
+			teststubbatchv1.WithLabels(map[string]string{"app": "foo"}),
+			teststubbatchv1.WithBackoffLimit(int32(2)),
+			teststubbatchv1.WithGeneratedSelector("1234"),
+			teststubbatchv1.WithCondition(batchv1.JobFailed, "BackoffLimitExceeded", "")),
+		want: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithNamespace("ns"),
+			teststubbatchv1.WithLabels(map[string]string{"app": "foo"}),
+			teststubbatchv1.WithBackoffLimit(int32(2))),
+	}, {
+		name: "manual selector kept",
+		job: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithNamespace("ns"),
+			teststubbatchv1.WithLabels(map[string]string{"shard-set": "a"}),
+			teststubbatchv1.WithManualSelector(map[string]string{"shard-set": "a"}),
+			teststubbatchv1.WithCondition(batchv1.JobComplete, "", "")),
+		want: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithNamespace("ns"),
+			teststubbatchv1.WithLabels(map[string]string{"shard-set": "a"}),
+			teststubbatchv1.WithManualSelector(map[string]string{"shard-set": "a"})),
+	}, {
+		name: "running job not rerun",
+		job: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithNamespace("ns"),
+			teststubbatchv1.WithActiveStatus()),
+		wantErr: "job foo has not finished, only complete or failed jobs can be rerun",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := testclient.NewSimpleClientset(&tc.job)
+			c := &batchClient{tbatchv1: cs.BatchV1(), ctx: context.Background()}
+			act, err := c.RerunJob("ns", "foo")
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, strings.HasPrefix(act.Name, "foo-rerun-"))
+			tc.want.Name = act.Name
+			assert.DeepEqual(t, &tc.want, act)
+		})
+	}
+}
+
+func TestDeleteJob(t *testing.T) {
+	for _, policy := range []metav1.DeletionPropagation{"", metav1.DeletePropagationForeground} {
+		job := teststubbatchv1.ConstructExpectedJobSpec(teststubbatchv1.WithNamespace("ns"))
+		cs := testclient.NewSimpleClientset(&job)
+		c := &batchClient{tbatchv1: cs.BatchV1(), ctx: context.Background()}
+		assert.NilError(t, c.DeleteJob("ns", "foo", policy))
+		_, err := c.tbatchv1.Jobs("ns").Get(c.ctx, "foo", metav1.GetOptions{})
+		assert.Assert(t, apierrs.IsNotFound(err))
+	}
+}
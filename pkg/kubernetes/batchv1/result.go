@@ -0,0 +1,61 @@
+package batchv1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	//JOB_RESULT_INVALID - error message to indicate the termination message is not valid json
+	JOB_RESULT_INVALID = "termination message of pod %s is not valid json: %v"
+)
+
+//GetJobResult returns the termination message of the succeeded and failed pods of the job
+//keyed by pod name, pods without a message are left out. When the pod has more than one
+//container the first terminated container with a message is used.
+//Set the message path and policy on the container with corev1.WithTerminationMessage
+func (c *batchClient) GetJobResult(ns, name string) (map[string]string, error) {
+	job, err := c.tbatchv1.Jobs(ns).Get(c.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.listJobPods(job)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]string)
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+				results[pod.Name] = status.State.Terminated.Message
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+//GetJobResultJSON decodes the job results as json, newResult returns a pointer
+//to the caller struct a result is decoded into. Results are keyed by pod name
+func (c *batchClient) GetJobResultJSON(ns, name string, newResult func() interface{}) (map[string]interface{}, error) {
+	results, err := c.GetJobResult(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	decoded := make(map[string]interface{}, len(results))
+	for pod, msg := range results {
+		result := newResult()
+		if err := json.Unmarshal([]byte(msg), result); err != nil {
+			return decoded, fmt.Errorf(JOB_RESULT_INVALID, pod, err)
+		}
+		decoded[pod] = result
+	}
+	return decoded, nil
+}
@@ -0,0 +1,93 @@
+package batchv1
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	teststubbatchv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/batchv1"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+type shardResult struct {
+	Rows  int    `json:"rows"`
+	Shard string `json:"shard"`
+}
+
+func jobResultObjects(messages ...string) []runtime.Object {
+	job := teststubbatchv1.ConstructExpectedJobSpec(teststubbatchv1.WithNamespace("ns"))
+	objs := []runtime.Object{&job,
+		teststubcorev1.ConstructPod("ns", "foo-running",
+			teststubcorev1.WithPodLabels(map[string]string{"job-name": "foo"}),
+			teststubcorev1.WithPodPhase(corev1.PodRunning),
+			teststubcorev1.WithRunningContainer("main")),
+		teststubcorev1.ConstructPod("ns", "bar-1",
+			teststubcorev1.WithPodLabels(map[string]string{"job-name": "bar"}),
+			teststubcorev1.WithPodPhase(corev1.PodSucceeded),
+			teststubcorev1.WithTerminatedContainer("main", int32(0), `{"rows": 1}`)),
+	}
+	phases := []corev1.PodPhase{corev1.PodSucceeded, corev1.PodFailed}
+	for i, msg := range messages {
+		objs = append(objs, teststubcorev1.ConstructPod("ns", "foo-"+string(rune('a'+i)),
+			teststubcorev1.WithPodLabels(map[string]string{"job-name": "foo"}),
+			teststubcorev1.WithPodPhase(phases[i%2]),
+			teststubcorev1.WithTerminatedContainer("sidecar", int32(0), ""),
+			teststubcorev1.WithTerminatedContainer("main", int32(i%2), msg)))
+	}
+	return objs
+}
+
+func TestGetJobResult(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		objects []runtime.Object
+		want    map[string]string
+		wantErr string
+	}{{
+		name:    "messages of finished pods",
+		objects: jobResultObjects(`{"rows": 10, "shard": "a"}`, "disk full", ""),
+		want: map[string]string{
+			"foo-a": `{"rows": 10, "shard": "a"}`,
+			"foo-b": "disk full",
+		},
+	}, {
+		name:    "job not found",
+		want:    nil,
+		wantErr: `jobs.batch "foo" not found`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := testclient.NewSimpleClientset(tc.objects...)
+			c := &batchClient{tbatchv1: cs.BatchV1(), tcorev1: cs.CoreV1(), ctx: context.Background()}
+			act, err := c.GetJobResult("ns", "foo")
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.want, act)
+		})
+	}
+}
+
+func TestGetJobResultJSON(t *testing.T) {
+	newResult := func() interface{} { return &shardResult{} }
+
+	cs := testclient.NewSimpleClientset(jobResultObjects(`{"rows": 10, "shard": "a"}`, `{"rows": 0, "shard": "b"}`)...)
+	c := &batchClient{tbatchv1: cs.BatchV1(), tcorev1: cs.CoreV1(), ctx: context.Background()}
+	act, err := c.GetJobResultJSON("ns", "foo", newResult)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]interface{}{
+		"foo-a": &shardResult{Rows: 10, Shard: "a"},
+		"foo-b": &shardResult{Rows: 0, Shard: "b"},
+	}, act)
+
+	cs = testclient.NewSimpleClientset(jobResultObjects("disk full")...)
+	c = &batchClient{tbatchv1: cs.BatchV1(), tcorev1: cs.CoreV1(), ctx: context.Background()}
+	_, err = c.GetJobResultJSON("ns", "foo", newResult)
+	assert.ErrorContains(t, err, "termination message of pod foo-a is not valid json")
+}
@@ -0,0 +1,119 @@
+package batchv1
+
+import (
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//JobState - overall state of a job
+type JobState string
+
+const (
+	JobPending   JobState = "Pending"
+	JobRunning   JobState = "Running"
+	JobSucceeded JobState = "Succeeded"
+	JobFailed    JobState = "Failed"
+	JobSuspended JobState = "Suspended"
+)
+
+//jobSuspended - condition newer clusters set on suspended jobs, batch/v1 in client-go v0.18 has no constant for it
+const jobSuspended batchv1.JobConditionType = "Suspended"
+
+//JobSummary - state of the job computed from its conditions and pods
+type JobSummary struct {
+	Name           string
+	State          JobState
+	StartTime      *metav1.Time
+	CompletionTime *metav1.Time
+	//Duration is the run time so far for active jobs
+	Duration  time.Duration
+	Active    int32
+	Succeeded int32
+	Failed    int32
+	//Reason and Message explain a failed or suspended job,
+	//for a pending job they come from the waiting container, like ImagePullBackOff
+	Reason  string
+	Message string
+}
+
+//GetJobSummary returns the summary of the job
+func (c *batchClient) GetJobSummary(ns, name string) (*JobSummary, error) {
+	job, err := c.tbatchv1.Jobs(ns).Get(c.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := c.listJobPods(job)
+	if err != nil {
+		return nil, err
+	}
+	return summarizeJob(job, pods, time.Now()), nil
+}
+
+func summarizeJob(job *batchv1.Job, pods []corev1.Pod, now time.Time) *JobSummary {
+	summary := &JobSummary{
+		Name:           job.Name,
+		StartTime:      job.Status.StartTime,
+		CompletionTime: job.Status.CompletionTime,
+		Active:         job.Status.Active,
+		Succeeded:      job.Status.Succeeded,
+		Failed:         job.Status.Failed,
+	}
+
+	var end *metav1.Time
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			summary.State = JobSucceeded
+			end = job.Status.CompletionTime
+		case batchv1.JobFailed:
+			summary.State = JobFailed
+			summary.Reason, summary.Message = cond.Reason, cond.Message
+			end = &cond.LastTransitionTime
+		case jobSuspended:
+			summary.State = JobSuspended
+			summary.Reason, summary.Message = cond.Reason, cond.Message
+		}
+	}
+	if summary.State == "" {
+		summary.State = JobPending
+		for _, pod := range pods {
+			if pod.Status.Phase == corev1.PodRunning {
+				summary.State = JobRunning
+				summary.Reason, summary.Message = "", ""
+				break
+			}
+			if summary.Reason == "" {
+				summary.Reason, summary.Message = waitingReason(pod)
+			}
+		}
+	}
+
+	if summary.StartTime != nil {
+		switch {
+		case end != nil && !end.IsZero():
+			summary.Duration = end.Sub(summary.StartTime.Time)
+		case summary.State == JobRunning || summary.State == JobPending:
+			summary.Duration = now.Sub(summary.StartTime.Time)
+		}
+	}
+	return summary
+}
+
+//waitingReason returns the reason of the first waiting container of the pod
+func waitingReason(pod corev1.Pod) (string, string) {
+	var statuses []corev1.ContainerStatus
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting != nil && status.State.Waiting.Reason != "" {
+			return status.State.Waiting.Reason, status.State.Waiting.Message
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,99 @@
+package batchv1
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	teststubbatchv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/batchv1"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+func TestSummarizeJob(t *testing.T) {
+	start := time.Date(2020, 10, 1, 10, 0, 0, 0, time.UTC)
+	now := start.Add(5 * time.Minute)
+	pendingPod := *teststubcorev1.ConstructPod("ns", "foo-1",
+		teststubcorev1.WithPodPhase(corev1.PodPending),
+		teststubcorev1.WithWaitingContainer("main", "ImagePullBackOff"))
+	runningPod := *teststubcorev1.ConstructPod("ns", "foo-2",
+		teststubcorev1.WithPodPhase(corev1.PodRunning),
+		teststubcorev1.WithRunningContainer("main"))
+
+	for _, tc := range []struct {
+		name string
+		job  batchv1.Job
+		pods []corev1.Pod
+		want JobSummary
+	}{{
+		name: "pending with waiting container",
+		job: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithTimes(start, time.Time{}),
+			teststubbatchv1.WithPodCounts(int32(1), int32(0), int32(0))),
+		pods: []corev1.Pod{pendingPod},
+		want: JobSummary{Name: "foo", State: JobPending, StartTime: &metav1.Time{Time: start},
+			Duration: 5 * time.Minute, Active: int32(1), Reason: "ImagePullBackOff"},
+	}, {
+		name: "running",
+		job: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithTimes(start, time.Time{}),
+			teststubbatchv1.WithPodCounts(int32(2), int32(0), int32(1))),
+		pods: []corev1.Pod{pendingPod, runningPod},
+		want: JobSummary{Name: "foo", State: JobRunning, StartTime: &metav1.Time{Time: start},
+			Duration: 5 * time.Minute, Active: int32(2), Failed: int32(1)},
+	}, {
+		name: "succeeded",
+		job: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithTimes(start, start.Add(2*time.Minute)),
+			teststubbatchv1.WithPodCounts(int32(0), int32(3), int32(0)),
+			teststubbatchv1.WithCondition(batchv1.JobComplete, "", "")),
+		want: JobSummary{Name: "foo", State: JobSucceeded, StartTime: &metav1.Time{Time: start},
+			CompletionTime: &metav1.Time{Time: start.Add(2 * time.Minute)}, Duration: 2 * time.Minute, Succeeded: int32(3)},
+	}, {
+		name: "failed",
+		job: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithTimes(start, time.Time{}),
+			teststubbatchv1.WithPodCounts(int32(0), int32(0), int32(4)),
+			teststubbatchv1.WithCondition(batchv1.JobFailed, "BackoffLimitExceeded", "Job has reached the specified backoff limit")),
+		want: JobSummary{Name: "foo", State: JobFailed, StartTime: &metav1.Time{Time: start}, Failed: int32(4),
+			Reason: "BackoffLimitExceeded", Message: "Job has reached the specified backoff limit"},
+	}, {
+		name: "suspended",
+		job: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithCondition("Suspended", "JobSuspended", "Job suspended")),
+		want: JobSummary{Name: "foo", State: JobSuspended, Reason: "JobSuspended", Message: "Job suspended"},
+	}, {
+		name: "not started",
+		job:  teststubbatchv1.ConstructExpectedJobSpec(),
+		want: JobSummary{Name: "foo", State: JobPending},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			act := summarizeJob(&tc.job, tc.pods, now)
+			assert.DeepEqual(t, &tc.want, act)
+		})
+	}
+}
+
+func TestGetJobSummary(t *testing.T) {
+	job := teststubbatchv1.ConstructExpectedJobSpec(
+		teststubbatchv1.WithNamespace("ns"),
+		teststubbatchv1.WithPodCounts(int32(1), int32(0), int32(0)))
+	cs := testclient.NewSimpleClientset(&job, teststubcorev1.ConstructPod("ns", "foo-1",
+		teststubcorev1.WithPodLabels(map[string]string{"job-name": "foo"}),
+		teststubcorev1.WithPodPhase(corev1.PodRunning),
+		teststubcorev1.WithRunningContainer("main")))
+	c := &batchClient{tbatchv1: cs.BatchV1(), tcorev1: cs.CoreV1(), ctx: context.Background()}
+	act, err := c.GetJobSummary("ns", "foo")
+	assert.NilError(t, err)
+	assert.Equal(t, act.State, JobRunning)
+	assert.Equal(t, act.Active, int32(1))
+
+	_, err = c.GetJobSummary("ns", "bar")
+	assert.ErrorContains(t, err, "not found")
+}
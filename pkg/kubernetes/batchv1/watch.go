@@ -0,0 +1,110 @@
+package batchv1
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+const (
+	//JOB_FAILED - error message to indicate the job failed
+	JOB_FAILED = "job %s failed with %d failed pods, reason: %s, message: %s"
+	//JOB_DELETED - error message to indicate the job was deleted while waiting on it
+	JOB_DELETED = "job %s was deleted before it finished"
+)
+
+//JobFailedError - carries the reason from the Failed condition of the job,
+//like BackoffLimitExceeded or DeadlineExceeded
+type JobFailedError struct {
+	Name    string
+	Reason  string
+	Message string
+	Failed  int32
+}
+
+func (e *JobFailedError) Error() string {
+	return fmt.Sprintf(JOB_FAILED, e.Name, e.Failed, e.Reason, e.Message)
+}
+
+//WaitForJob watches the job till it has the Complete or Failed condition.
+//A failed job returns *JobFailedError, a deleted job JOB_DELETED, cancelling the client context stops the wait
+func (c *batchClient) WaitForJob(ns, name string) (*batchv1.Job, error) {
+	job, err := c.tbatchv1.Jobs(ns).Get(c.ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if done, err := jobFinished(job); done {
+			return job, err
+		}
+		if err := c.ctx.Err(); err != nil {
+			return job, err
+		}
+		w, err := c.tbatchv1.Jobs(ns).Watch(c.ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+			ResourceVersion: job.ResourceVersion,
+		})
+		if err != nil {
+			return job, err
+		}
+		job, err = c.watchJob(c.ctx, w, job)
+		w.Stop()
+		if err != nil {
+			return job, err
+		}
+	}
+}
+
+//watchJob returns once the job finished or was deleted or the watch is closed
+func (c *batchClient) watchJob(ctx context.Context, w watch.Interface, job *batchv1.Job) (*batchv1.Job, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return job, ctx.Err()
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				return job, nil
+			}
+			if event.Type == watch.Error {
+				return job, fmt.Errorf("watching job %s failed: %v", job.Name, event.Object)
+			}
+			updated, ok := event.Object.(*batchv1.Job)
+			if !ok || updated.Name != job.Name {
+				continue
+			}
+			job = updated
+			if event.Type == watch.Deleted {
+				return job, fmt.Errorf(JOB_DELETED, job.Name)
+			}
+			if done, err := jobFinished(job); done {
+				return job, err
+			}
+		}
+	}
+}
+
+//jobFinished returns true once the job is complete or failed
+func jobFinished(job *batchv1.Job) (bool, error) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			return true, &JobFailedError{
+				Name:    job.Name,
+				Reason:  cond.Reason,
+				Message: cond.Message,
+				Failed:  job.Status.Failed,
+			}
+		}
+	}
+	return false, nil
+}
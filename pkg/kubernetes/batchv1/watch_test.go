@@ -0,0 +1,117 @@
+package batchv1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+
+	batchv1 "k8s.io/api/batch/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	teststubbatchv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/batchv1"
+)
+
+func TestCreateJobWithWatch(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		watch   bool
+		events  []batchv1.Job
+		wantJob batchv1.Job
+		wantErr error
+	}{{
+		name:    "no watch",
+		wantJob: teststubbatchv1.ConstructExpectedJobSpec(teststubbatchv1.WithNamespace("ns")),
+	}, {
+		name:  "job completes",
+		watch: true,
+		events: []batchv1.Job{
+			teststubbatchv1.ConstructExpectedJobSpec(
+				teststubbatchv1.WithNamespace("ns"),
+				teststubbatchv1.WithPodCounts(int32(1), int32(0), int32(0))),
+			teststubbatchv1.ConstructExpectedJobSpec(
+				teststubbatchv1.WithNamespace("ns"),
+				teststubbatchv1.WithPodCounts(int32(0), int32(1), int32(0)),
+				teststubbatchv1.WithCondition(batchv1.JobComplete, "", "")),
+		},
+		wantJob: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithNamespace("ns"),
+			teststubbatchv1.WithPodCounts(int32(0), int32(1), int32(0)),
+			teststubbatchv1.WithCondition(batchv1.JobComplete, "", "")),
+	}, {
+		name:  "job fails",
+		watch: true,
+		events: []batchv1.Job{
+			teststubbatchv1.ConstructExpectedJobSpec(
+				teststubbatchv1.WithNamespace("ns"),
+				teststubbatchv1.WithPodCounts(int32(0), int32(0), int32(3)),
+				teststubbatchv1.WithCondition(batchv1.JobFailed, "BackoffLimitExceeded", "Job has reached the specified backoff limit")),
+		},
+		wantJob: teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithNamespace("ns"),
+			teststubbatchv1.WithPodCounts(int32(0), int32(0), int32(3)),
+			teststubbatchv1.WithCondition(batchv1.JobFailed, "BackoffLimitExceeded", "Job has reached the specified backoff limit")),
+		wantErr: &JobFailedError{
+			Name:    "foo",
+			Reason:  "BackoffLimitExceeded",
+			Message: "Job has reached the specified backoff limit",
+			Failed:  int32(3),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := testclient.NewSimpleClientset()
+			fw := watch.NewFake()
+			cs.PrependWatchReactor("jobs", func(action clienttesting.Action) (bool, watch.Interface, error) {
+				return true, fw, nil
+			})
+			events := tc.events
+			go func() {
+				for i := range events {
+					fw.Modify(&events[i])
+				}
+			}()
+			c := &batchClient{tbatchv1: cs.BatchV1(), ctx: context.Background()}
+			job := teststubbatchv1.ConstructExpectedJobSpec()
+			act, err := c.CreateJob("ns", &job, tc.watch)
+			if tc.wantErr == nil {
+				assert.NilError(t, err)
+			} else {
+				assert.DeepEqual(t, tc.wantErr, err)
+			}
+			assert.DeepEqual(t, &tc.wantJob, act)
+		})
+	}
+}
+
+func TestWaitForJobCancelled(t *testing.T) {
+	cs := testclient.NewSimpleClientset()
+	cs.PrependWatchReactor("jobs", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		return true, watch.NewFake(), nil
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	c := &batchClient{tbatchv1: cs.BatchV1(), ctx: ctx}
+	job := teststubbatchv1.ConstructExpectedJobSpec()
+	_, err := c.CreateJob("ns", &job, true)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestWaitForJobDeleted(t *testing.T) {
+	cs := testclient.NewSimpleClientset()
+	fw := watch.NewFake()
+	cs.PrependWatchReactor("jobs", func(action clienttesting.Action) (bool, watch.Interface, error) {
+		return true, fw, nil
+	})
+	deleted := teststubbatchv1.ConstructExpectedJobSpec(teststubbatchv1.WithNamespace("ns"))
+	go fw.Delete(&deleted)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	c := &batchClient{tbatchv1: cs.BatchV1(), ctx: ctx}
+	job := teststubbatchv1.ConstructExpectedJobSpec()
+	_, err := c.CreateJob("ns", &job, true)
+	assert.Error(t, err, fmt.Sprintf(JOB_DELETED, "foo"))
+}
@@ -0,0 +1,191 @@
+package batchv1beta1
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	typedbatchv1 "k8s.io/client-go/kubernetes/typed/batch/v1"
+	typedbatchv1beta1 "k8s.io/client-go/kubernetes/typed/batch/v1beta1"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/robfig/cron/v3"
+
+	"knative.dev/pkg/ptr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	jobv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/batchv1"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
+)
+
+const (
+	//INVALID_SCHEDULE - error message to indicate the cron schedule can not be parsed
+	INVALID_SCHEDULE = "invalid schedule %q: %v"
+	//manualJobAnnotation - marks the jobs created outside the schedule, same as kubectl create job --from
+	manualJobAnnotation = "cronjob.kubernetes.io/instantiate"
+)
+
+type CronJobSpecOption func(*batchv1beta1.CronJob)
+
+type cronClient struct {
+	tbatchv1beta1 typedbatchv1beta1.BatchV1beta1Interface
+	tbatchv1      typedbatchv1.BatchV1Interface
+	ctx           context.Context
+}
+
+func Client(c context.Context) *cronClient {
+	cs := kubernetes.KubernetesCSFromContext(c)
+	return &cronClient{
+		tbatchv1beta1: cs.BatchV1beta1(),
+		tbatchv1:      cs.BatchV1(),
+		ctx:           c,
+	}
+}
+
+//GetCronJob returns the cron job for the name and namespace
+func (c *cronClient) GetCronJob(ns, name string) (*batchv1beta1.CronJob, error) {
+	return c.tbatchv1beta1.CronJobs(ns).Get(c.ctx, name, metav1.GetOptions{})
+}
+
+//CreateCronJob creates the cron job in the namespace
+func (c *cronClient) CreateCronJob(ns string, cronJob *batchv1beta1.CronJob) (*batchv1beta1.CronJob, error) {
+	return c.tbatchv1beta1.CronJobs(ns).Create(c.ctx, cronJob, metav1.CreateOptions{})
+}
+
+//ApplyCronJob creates the cron job if it is not present otherwise updates
+//the spec, labels, annotations and owner references are copied on to the existing cron job.
+//update is retried on conflict
+func (c *cronClient) ApplyCronJob(ns string, cronJob *batchv1beta1.CronJob) (*batchv1beta1.CronJob, error) {
+	var applied *batchv1beta1.CronJob
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		existing, err := c.GetCronJob(ns, cronJob.Name)
+		if apierrs.IsNotFound(err) {
+			applied, err = c.CreateCronJob(ns, cronJob)
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		desired := existing.DeepCopy()
+		desired.Spec = *cronJob.Spec.DeepCopy()
+		transform.ApplyMeta(&desired.ObjectMeta, cronJob.ObjectMeta)
+		applied, err = c.tbatchv1beta1.CronJobs(ns).Update(c.ctx, desired, metav1.UpdateOptions{})
+		return err
+	})
+	return applied, err
+}
+
+//TriggerCronJob creates a job from the job template of the cron job right away,
+//the job is owned by the cron job and annotated as manually instantiated
+func (c *cronClient) TriggerCronJob(ns, name string) (*batchv1.Job, error) {
+	cronJob, err := c.GetCronJob(ns, name)
+	if err != nil {
+		return nil, err
+	}
+	return c.tbatchv1.Jobs(ns).Create(c.ctx, jobFromCronJob(cronJob), metav1.CreateOptions{})
+}
+
+func jobFromCronJob(cronJob *batchv1beta1.CronJob) *batchv1.Job {
+	suffix := "-manual-" + utilrand.String(5)
+	prefix := cronJob.Name
+	if len(prefix)+len(suffix) > 63 {
+		prefix = prefix[:63-len(suffix)]
+	}
+	annotations := map[string]string{manualJobAnnotation: "manual"}
+	for k, v := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+	gvk := batchv1beta1.SchemeGroupVersion.WithKind("CronJob")
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            prefix + suffix,
+			Namespace:       cronJob.Namespace,
+			Labels:          cronJob.Spec.JobTemplate.Labels,
+			Annotations:     annotations,
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(cronJob, gvk)},
+		},
+		Spec: *cronJob.Spec.JobTemplate.Spec.DeepCopy(),
+	}
+}
+
+//GetCronJob construct cron job spec based on option provided
+func GetCronJob(name string, options ...CronJobSpecOption) batchv1beta1.CronJob {
+	cronJob := batchv1beta1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CronJob",
+			APIVersion: "batch/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&cronJob)
+	}
+	return cronJob
+}
+
+//WithJobOptions - job template built with the batchv1 job options
+func WithJobOptions(options ...jobv1.JobSpecOption) CronJobSpecOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		job := jobv1.GetJob("", options...)
+		cronJob.Spec.JobTemplate.ObjectMeta.Labels = job.ObjectMeta.Labels
+		cronJob.Spec.JobTemplate.ObjectMeta.Annotations = job.ObjectMeta.Annotations
+		cronJob.Spec.JobTemplate.Spec = job.Spec
+	}
+}
+
+//WithSchedule - cron schedule, validated with the standard 5 field syntax and the @ descriptors
+func WithSchedule(schedule string) (CronJobSpecOption, error) {
+	if _, err := cron.ParseStandard(schedule); err != nil {
+		return nil, fmt.Errorf(INVALID_SCHEDULE, schedule, err)
+	}
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.Spec.Schedule = schedule
+	}, nil
+}
+
+//WithConcurrencyPolicy - Allow, Forbid or Replace
+func WithConcurrencyPolicy(policy batchv1beta1.ConcurrencyPolicy) CronJobSpecOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		if policy != "" {
+			cronJob.Spec.ConcurrencyPolicy = policy
+		}
+	}
+}
+
+//WithSuspend - suspend the subsequent runs
+func WithSuspend(suspend bool) CronJobSpecOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.Spec.Suspend = ptr.Bool(suspend)
+	}
+}
+
+//WithStartingDeadline - seconds after the schedule a missed run can still start
+func WithStartingDeadline(seconds int64) CronJobSpecOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		if seconds > int64(0) {
+			cronJob.Spec.StartingDeadlineSeconds = ptr.Int64(seconds)
+		}
+	}
+}
+
+//WithHistoryLimits - number of successful and failed jobs to keep
+func WithHistoryLimits(successful, failed int32) CronJobSpecOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		if successful >= int32(0) {
+			cronJob.Spec.SuccessfulJobsHistoryLimit = ptr.Int32(successful)
+		}
+		if failed >= int32(0) {
+			cronJob.Spec.FailedJobsHistoryLimit = ptr.Int32(failed)
+		}
+	}
+}
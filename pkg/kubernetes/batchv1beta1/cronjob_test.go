@@ -0,0 +1,168 @@
+package batchv1beta1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	jobv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/batchv1"
+	corev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes/corev1"
+	teststubbatchv1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/batchv1"
+	teststubbatchv1beta1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/batchv1beta1"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+func TestGetCronJob(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		want         batchv1beta1.CronJob
+		inputOptions []CronJobSpecOption
+	}{{
+		name: "cron job with all options",
+		want: teststubbatchv1beta1.ConstructExpectedCronJob(
+			teststubbatchv1beta1.WithJobTemplate(teststubbatchv1.ConstructExpectedJobSpec(
+				teststubbatchv1.WithPodSpecOptions(
+					teststubcorev1.WithServiceAccount("admin-sa"),
+					teststubcorev1.WithRestartPolicy("Never")),
+				teststubbatchv1.WithTTL(int32(100)),
+				teststubbatchv1.WithLabels(map[string]string{"key1": "val1"}),
+				teststubbatchv1.WithBackoffLimit(int32(1)))),
+			teststubbatchv1beta1.WithSchedule("*/5 * * * *"),
+			teststubbatchv1beta1.WithConcurrencyPolicy(batchv1beta1.ForbidConcurrent),
+			teststubbatchv1beta1.WithSuspend(true),
+			teststubbatchv1beta1.WithStartingDeadline(int64(60)),
+			teststubbatchv1beta1.WithHistoryLimits(int32(3), int32(1))),
+		inputOptions: []CronJobSpecOption{
+			WithJobOptions(
+				jobv1.WithPodSpecOptions(kubernetes.PodSpec{},
+					corev1.WithServiceAccount("admin-sa"),
+					corev1.WithRestartPolicy("Never")),
+				jobv1.WithTTL(int32(100)),
+				jobv1.WithLabels([]kubernetes.KV{{"key1", "val1"}}),
+				jobv1.WithBackoffLimit(int32(1))),
+			mustSchedule(t, "*/5 * * * *"),
+			WithConcurrencyPolicy(batchv1beta1.ForbidConcurrent),
+			WithSuspend(true),
+			WithStartingDeadline(int64(60)),
+			WithHistoryLimits(int32(3), int32(1)),
+		},
+	}, {
+		name: "cron job with null options",
+		want: teststubbatchv1beta1.ConstructExpectedCronJob(
+			teststubbatchv1beta1.WithSchedule("@hourly"),
+			teststubbatchv1beta1.WithHistoryLimits(int32(0), int32(0))),
+		inputOptions: []CronJobSpecOption{
+			mustSchedule(t, "@hourly"),
+			WithConcurrencyPolicy(""),
+			WithStartingDeadline(int64(0)),
+			WithHistoryLimits(int32(0), int32(0)),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			act := GetCronJob("foo", tc.inputOptions...)
+			assert.DeepEqual(t, &tc.want, &act)
+		})
+	}
+}
+
+func TestWithScheduleInvalid(t *testing.T) {
+	for _, schedule := range []string{"", "* * *", "61 * * * *", "@every-day"} {
+		t.Run(schedule, func(t *testing.T) {
+			_, err := WithSchedule(schedule)
+			assert.ErrorContains(t, err, "invalid schedule")
+		})
+	}
+}
+
+func TestApplyCronJob(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		want           batchv1beta1.CronJob
+		input          batchv1beta1.CronJob
+		runtimeObjects []runtime.Object
+	}{{
+		name: "cron job created when missing",
+		want: teststubbatchv1beta1.ConstructExpectedCronJob(
+			teststubbatchv1beta1.WithNamespace("ns"),
+			teststubbatchv1beta1.WithSchedule("@daily")),
+		input: teststubbatchv1beta1.ConstructExpectedCronJob(
+			teststubbatchv1beta1.WithNamespace("ns"),
+			teststubbatchv1beta1.WithSchedule("@daily")),
+	}, {
+		name: "spec updated and labels merged",
+		want: teststubbatchv1beta1.ConstructExpectedCronJob(
+			teststubbatchv1beta1.WithNamespace("ns"),
+			teststubbatchv1beta1.WithLabels(map[string]string{"k1": "v1", "k2": "v2"}),
+			teststubbatchv1beta1.WithSchedule("@hourly")),
+		input: teststubbatchv1beta1.ConstructExpectedCronJob(
+			teststubbatchv1beta1.WithNamespace("ns"),
+			teststubbatchv1beta1.WithLabels(map[string]string{"k2": "v2"}),
+			teststubbatchv1beta1.WithSchedule("@hourly")),
+		runtimeObjects: []runtime.Object{cronJobPtr(teststubbatchv1beta1.ConstructExpectedCronJob(
+			teststubbatchv1beta1.WithNamespace("ns"),
+			teststubbatchv1beta1.WithLabels(map[string]string{"k1": "v1"}),
+			teststubbatchv1beta1.WithSchedule("@daily")))},
+	}, {
+		name: "owner reference carried over",
+		want: teststubbatchv1beta1.ConstructExpectedCronJob(
+			teststubbatchv1beta1.WithNamespace("ns"),
+			teststubbatchv1beta1.WithOwnerReference(metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "1234"}),
+			teststubbatchv1beta1.WithSchedule("@hourly")),
+		input: teststubbatchv1beta1.ConstructExpectedCronJob(
+			teststubbatchv1beta1.WithNamespace("ns"),
+			teststubbatchv1beta1.WithOwnerReference(metav1.OwnerReference{APIVersion: "v1", Kind: "ConfigMap", Name: "owner", UID: "1234"}),
+			teststubbatchv1beta1.WithSchedule("@hourly")),
+		runtimeObjects: []runtime.Object{cronJobPtr(teststubbatchv1beta1.ConstructExpectedCronJob(
+			teststubbatchv1beta1.WithNamespace("ns"),
+			teststubbatchv1beta1.WithSchedule("@daily")))},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			cs := testclient.NewSimpleClientset(tc.runtimeObjects...)
+			c := &cronClient{tbatchv1beta1: cs.BatchV1beta1(), ctx: context.Background()}
+			act, err := c.ApplyCronJob("ns", &tc.input)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, &tc.want, act)
+		})
+	}
+}
+
+func TestTriggerCronJob(t *testing.T) {
+	cronJob := teststubbatchv1beta1.ConstructExpectedCronJob(
+		teststubbatchv1beta1.WithNamespace("ns"),
+		teststubbatchv1beta1.WithSchedule("@daily"),
+		teststubbatchv1beta1.WithJobTemplate(teststubbatchv1.ConstructExpectedJobSpec(
+			teststubbatchv1.WithLabels(map[string]string{"app": "foo"}),
+			teststubbatchv1.WithBackoffLimit(int32(2)))))
+	cs := testclient.NewSimpleClientset(&cronJob)
+	c := &cronClient{tbatchv1beta1: cs.BatchV1beta1(), tbatchv1: cs.BatchV1(), ctx: context.Background()}
+
+	job, err := c.TriggerCronJob("ns", "foo")
+	assert.NilError(t, err)
+	assert.Assert(t, strings.HasPrefix(job.Name, "foo-manual-"))
+	assert.Equal(t, job.Annotations["cronjob.kubernetes.io/instantiate"], "manual")
+	assert.DeepEqual(t, job.Spec, cronJob.Spec.JobTemplate.Spec)
+	assert.Equal(t, len(job.OwnerReferences), 1)
+	assert.Equal(t, job.OwnerReferences[0].Kind, "CronJob")
+	assert.Equal(t, job.OwnerReferences[0].Name, "foo")
+
+	_, err = c.TriggerCronJob("ns", "bar")
+	assert.ErrorContains(t, err, "not found")
+}
+
+func mustSchedule(t *testing.T, schedule string) CronJobSpecOption {
+	opt, err := WithSchedule(schedule)
+	assert.NilError(t, err)
+	return opt
+}
+
+func cronJobPtr(cronJob batchv1beta1.CronJob) *batchv1beta1.CronJob {
+	return &cronJob
+}
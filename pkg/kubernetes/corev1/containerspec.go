@@ -32,12 +32,54 @@ func GetContainerSpec(spec kubernetes.ContainerSpec, options ...ContainerSpecOpt
 	cSpec := corev1.Container{
 		Image: spec.Image,
 	}
+	WithProbes(spec.Probes)(&cSpec)
 	for _, fn := range options {
 		fn(&cSpec)
 	}
 	return cSpec
 }
 
+//GetContainerFromSpec construct container spec with all the fields of the spec,
+//options are applied after the fields of the spec. An invalid spec returns the
+//error of ValidateContainerSpec
+func GetContainerFromSpec(spec kubernetes.ContainerSpec, options ...ContainerSpecOption) (corev1.Container, error) {
+	if err := ValidateContainerSpec(spec); err != nil {
+		return corev1.Container{}, err
+	}
+	return containerFromSpec(spec, options...), nil
+}
+
+//ValidateContainerSpec reports the declarations of the spec which the
+//options would leave out, like unknown env from types or probe handlers
+func ValidateContainerSpec(spec kubernetes.ContainerSpec) error {
+	if _, err := GetEnvfromSecretorCM(spec.EnvFromSecretorCM); err != nil {
+		return err
+	}
+	if _, err := GetEnvValueFrom(spec.EnvValueFrom); err != nil {
+		return err
+	}
+	if _, err := GetVolumes(spec.Volumes); err != nil {
+		return err
+	}
+	return ValidateProbes(spec.Probes)
+}
+
+func containerFromSpec(spec kubernetes.ContainerSpec, options ...ContainerSpecOption) corev1.Container {
+	specOptions := []ContainerSpecOption{
+		WithName(spec.Name),
+		WithCommand(spec.Cmd),
+		WithEnv(spec.EnvVariables),
+		WithEnvFromSecretorCM(spec.EnvFromSecretorCM),
+		WithEnvValueFrom(spec.EnvValueFrom),
+		WithVolumeMounts(spec.ConfigMaps, spec.Secrets),
+		WithMounts(spec.Volumes),
+		WithNamedPort(PortName(spec), spec.Port),
+		WithSecurityContext(spec.User),
+		WithResources(spec.Resources),
+	}
+	return GetContainerSpec(spec, append(specOptions, options...)...)
+}
+
 //WithEnv attach env variables
 func WithEnv(envs []corev1.EnvVar) ContainerSpecOption {
 	return func(container *corev1.Container) {
@@ -47,14 +89,54 @@ func WithEnv(envs []corev1.EnvVar) ContainerSpecOption {
 	}
 }
 
-//WithEnvFromSecretorCM attach secret/cm as env
+//WithEnvValueFrom attach env variables from secret/cm keys, pod fields and container resources,
+//entries with an unknown type are left out, ValidateContainerSpec reports them
+func WithEnvValueFrom(envValueFrom []kubernetes.EnvValueFrom) ContainerSpecOption {
+	envVars, _ := GetEnvValueFrom(envValueFrom)
+	return WithEnv(envVars)
+}
+
+//WithSecretKeyEnv - env variable from the key of the secret
+func WithSecretKeyEnv(envName, secret, key string, optional bool) ContainerSpecOption {
+	return WithEnvValueFrom([]kubernetes.EnvValueFrom{{EnvName: envName, Type: kubernetes.EnvValueFromSecret, Name: secret, Key: key, Optional: optional}})
+}
+
+//WithConfigMapKeyEnv - env variable from the key of the cm
+func WithConfigMapKeyEnv(envName, cm, key string, optional bool) ContainerSpecOption {
+	return WithEnvValueFrom([]kubernetes.EnvValueFrom{{EnvName: envName, Type: kubernetes.EnvValueFromCM, Name: cm, Key: key, Optional: optional}})
+}
+
+//WithFieldRefEnv - env variable from a pod field, like FieldNamespace or FieldPodIP
+func WithFieldRefEnv(envName, fieldPath string) ContainerSpecOption {
+	return WithEnvValueFrom([]kubernetes.EnvValueFrom{{EnvName: envName, Type: kubernetes.EnvValueFromField, Key: fieldPath}})
+}
+
+//WithResourceFieldRefEnv - env variable from a resource of the container, like limits.memory,
+//empty container name refers to the container the env is set on
+func WithResourceFieldRefEnv(envName, containerName, resource string) ContainerSpecOption {
+	return WithEnvValueFrom([]kubernetes.EnvValueFrom{{EnvName: envName, Type: kubernetes.EnvValueFromResource, Name: containerName, Key: resource}})
+}
+
+//WithEnvFromSecretorCM attach secret/cm as env, entries with an unknown type are left out,
+//ValidateContainerSpec and WithValidEnvFromSecretorCM report them
 func WithEnvFromSecretorCM(envFromSecretorCM []kubernetes.EnvFrom) ContainerSpecOption {
 	return func(container *corev1.Container) {
-		envList := GetEnvfromSecretorCM(envFromSecretorCM)
+		envList, _ := GetEnvfromSecretorCM(envFromSecretorCM)
 		container.EnvFrom = envList
 	}
 }
 
+//WithValidEnvFromSecretorCM attach secret/cm as env, unknown types return an error
+func WithValidEnvFromSecretorCM(envFromSecretorCM []kubernetes.EnvFrom) (ContainerSpecOption, error) {
+	envList, err := GetEnvfromSecretorCM(envFromSecretorCM)
+	if err != nil {
+		return nil, err
+	}
+	return func(container *corev1.Container) {
+		container.EnvFrom = envList
+	}, nil
+}
+
 //WithVolumeMounts mount cm/secret as volume
 func WithVolumeMounts(cms []corev1.VolumeMount, secrets []corev1.VolumeMount) ContainerSpecOption {
 	return func(container *corev1.Container) {
@@ -63,6 +145,13 @@ func WithVolumeMounts(cms []corev1.VolumeMount, secrets []corev1.VolumeMount) Co
 	}
 }
 
+//WithMounts mount the cm/secret volume declarations, add it after WithVolumeMounts
+func WithMounts(volumes []kubernetes.Volume) ContainerSpecOption {
+	return func(container *corev1.Container) {
+		container.VolumeMounts = append(container.VolumeMounts, GetMounts(volumes)...)
+	}
+}
+
 //WithPort appends the container port
 func WithPort(port int32) ContainerSpecOption {
 	return func(container *corev1.Container) {
@@ -74,6 +163,18 @@ func WithPort(port int32) ContainerSpecOption {
 	}
 }
 
+//WithNamedPort appends the container port with a name services can target
+func WithNamedPort(name string, port int32) ContainerSpecOption {
+	return func(container *corev1.Container) {
+		if port > 0 {
+			container.Ports = append(container.Ports, corev1.ContainerPort{
+				Name:          name,
+				ContainerPort: port,
+			})
+		}
+	}
+}
+
 //WithSecurityContext attached pod security policy
 func WithSecurityContext(user int64) ContainerSpecOption {
 	return func(container *corev1.Container) {
@@ -112,6 +213,19 @@ func WithImagePullPolicy(pullPolicy corev1.PullPolicy) ContainerSpecOption {
 	}
 }
 
+//WithTerminationMessage - file the container writes its result to and the policy,
+//corev1.TerminationMessageFallbackToLogsOnError uses the log tail when the file is empty on error
+func WithTerminationMessage(path string, policy corev1.TerminationMessagePolicy) ContainerSpecOption {
+	return func(container *corev1.Container) {
+		if path != "" {
+			container.TerminationMessagePath = path
+		}
+		if policy != "" {
+			container.TerminationMessagePolicy = policy
+		}
+	}
+}
+
 //WithResources - container resource constraints
 func WithResources(resources []kubernetes.Resource) ContainerSpecOption {
 	return func(container *corev1.Container) {
@@ -1,12 +1,15 @@
 package corev1
 
 import (
+	"fmt"
 	"testing"
 
 	"gotest.tools/assert"
 
 	corev1 "k8s.io/api/core/v1"
 
+	"knative.dev/pkg/ptr"
+
 	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
 	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
 )
@@ -21,27 +24,39 @@ func TestGetContainerSpec(t *testing.T) {
 		name: "Container will All Options",
 		wantContainer: teststubcorev1.ConstructExpectedContainerSpec(
 			teststubcorev1.WithEnv([]string{"e1", "e2"}, []string{"v1", "v2"}),
+			teststubcorev1.WithSecretKeyEnv("e3", "s1", "token", nil),
+			teststubcorev1.WithConfigMapKeyEnv("e4", "c1", "level", ptr.Bool(true)),
+			teststubcorev1.WithFieldRefEnv("e5", "status.podIP"),
+			teststubcorev1.WithResourceFieldRefEnv("e6", "", "limits.memory"),
 			teststubcorev1.WithEnvFromSecretorCM([]string{"c1", "s1"}, []string{"CM", "Secret"}),
 			teststubcorev1.WithVolumeMounts([]string{"c1", "s1"}, []string{"/p1", "/p2"}),
 			teststubcorev1.WithPort(int32(8080)),
 			teststubcorev1.WithPort(int32(9090)),
+			teststubcorev1.WithNamedPort("metrics", int32(9091)),
 			teststubcorev1.WithSecurityContext(int64(1001)),
 			teststubcorev1.WithName("foo"),
 			teststubcorev1.WithImage("docker.com/bar"),
 			teststubcorev1.WithCommand([]string{"python", "some.py"}),
 			teststubcorev1.WithImagePullPolicy(corev1.PullAlways),
+			teststubcorev1.WithTerminationMessage("/tmp/result", corev1.TerminationMessageFallbackToLogsOnError),
 			teststubcorev1.WithResources(int64(10), int64(50), int64(128), int64(256))),
 		inputModel: kubernetes.ContainerSpec{Image: "docker.com/bar"},
 		inputOptions: []ContainerSpecOption{
 			WithEnv([]corev1.EnvVar{{Name: "e1", Value: "v1"}, {Name: "e2", Value: "v2"}}),
-			WithEnvFromSecretorCM([]kubernetes.EnvFrom{{"c1", "CM"}, {"s1", "Secret"}}),
+			WithSecretKeyEnv("e3", "s1", "token", false),
+			WithConfigMapKeyEnv("e4", "c1", "level", true),
+			WithFieldRefEnv("e5", FieldPodIP),
+			WithResourceFieldRefEnv("e6", "", "limits.memory"),
+			WithEnvFromSecretorCM([]kubernetes.EnvFrom{{Name: "c1", Type: "CM"}, {Name: "s1", Type: "Secret"}}),
 			WithVolumeMounts(teststubcorev1.ConstructMounts([]string{"c1"}, []string{"/p1"}), teststubcorev1.ConstructMounts([]string{"s1"}, []string{"/p2"})),
 			WithPort(int32(8080)),
 			WithPort(int32(9090)),
+			WithNamedPort("metrics", int32(9091)),
 			WithSecurityContext(int64(1001)),
 			WithName("foo"),
 			WithCommand([]string{"python", "some.py"}),
 			WithImagePullPolicy(corev1.PullAlways),
+			WithTerminationMessage("/tmp/result", corev1.TerminationMessageFallbackToLogsOnError),
 			WithResources([]kubernetes.Resource{{"Requests", int64(10), int64(128)}, {"Limit", int64(50), int64(256)}}),
 		},
 	}, {
@@ -51,12 +66,15 @@ func TestGetContainerSpec(t *testing.T) {
 		inputModel: kubernetes.ContainerSpec{Image: "docker.com/bar"},
 		inputOptions: []ContainerSpecOption{
 			WithEnv([]corev1.EnvVar{{Name: "", Value: ""}}),
-			WithEnvFromSecretorCM([]kubernetes.EnvFrom{{"", ""}}),
+			WithEnvFromSecretorCM([]kubernetes.EnvFrom{{Name: "", Type: ""}}),
+			WithEnvValueFrom([]kubernetes.EnvValueFrom{{Type: "Field", Key: FieldPodIP}, {EnvName: "e1", Type: "config"}}),
 			WithVolumeMounts(teststubcorev1.ConstructMounts([]string{""}, []string{""}), teststubcorev1.ConstructMounts([]string{""}, []string{""})),
 			WithPort(int32(0)),
+			WithNamedPort("metrics", int32(0)),
 			WithSecurityContext(int64(0)),
 			WithName(""),
 			WithCommand([]string{""}),
+			WithTerminationMessage("", ""),
 			WithResources([]kubernetes.Resource{{"", int64(0), int64(0)}}),
 		},
 	}, {
@@ -93,3 +111,51 @@ func TestGetContainerSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestGetContainerFromSpec(t *testing.T) {
+	for _, tc := range []struct {
+		name          string
+		wantContainer corev1.Container
+		wantErr       string
+		inputModel    kubernetes.ContainerSpec
+	}{{
+		name: "Container with env sources",
+		wantContainer: teststubcorev1.ConstructExpectedContainerSpec(
+			teststubcorev1.WithName("foo"),
+			teststubcorev1.WithImage("docker.com/bar"),
+			teststubcorev1.WithEnvFromSecretorCM([]string{"s1"}, []string{"Secret"}),
+			teststubcorev1.WithFieldRefEnv("NS", FieldNamespace),
+			teststubcorev1.WithNamedPort("foo", int32(8080))),
+		inputModel: kubernetes.ContainerSpec{
+			Name:              "foo",
+			Image:             "docker.com/bar",
+			Port:              int32(8080),
+			EnvFromSecretorCM: []kubernetes.EnvFrom{{Name: "s1", Type: kubernetes.EnvFromSecret}},
+			EnvValueFrom:      []kubernetes.EnvValueFrom{{EnvName: "NS", Type: kubernetes.EnvValueFromField, Key: FieldNamespace}},
+		},
+	}, {
+		name:    "unknown env from type",
+		wantErr: fmt.Sprintf(INVALID_ENV_FROM_TYPE_OF, "s1"),
+		inputModel: kubernetes.ContainerSpec{
+			Image:             "docker.com/bar",
+			EnvFromSecretorCM: []kubernetes.EnvFrom{{Name: "s1", Type: "secret"}},
+		},
+	}, {
+		name:    "unknown env value from type",
+		wantErr: fmt.Sprintf(INVALID_ENV_VALUE_FROM_TYPE, "NS"),
+		inputModel: kubernetes.ContainerSpec{
+			Image:        "docker.com/bar",
+			EnvValueFrom: []kubernetes.EnvValueFrom{{EnvName: "NS", Type: "field", Key: FieldNamespace}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			actContainer, err := GetContainerFromSpec(tc.inputModel)
+			if tc.wantErr != "" {
+				assert.Error(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, &tc.wantContainer, &actContainer)
+		})
+	}
+}
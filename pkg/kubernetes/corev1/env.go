@@ -1,39 +1,67 @@
 package corev1
 
 import (
+	"fmt"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
+	"knative.dev/pkg/ptr"
+
 	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
 )
 
-func GetEnvfromSecretorCM(envFrom []kubernetes.EnvFrom) []corev1.EnvFromSource {
+const (
+	//FieldPodName - downward api field path of the pod name
+	FieldPodName = "metadata.name"
+	//FieldNamespace - downward api field path of the pod namespace
+	FieldNamespace = "metadata.namespace"
+	//FieldPodIP - downward api field path of the pod ip
+	FieldPodIP = "status.podIP"
+	//FieldNodeName - downward api field path of the node the pod runs on
+	FieldNodeName = "spec.nodeName"
+	//FieldServiceAccount - downward api field path of the pod service account
+	FieldServiceAccount = "spec.serviceAccountName"
+)
+
+//GetEnvfromSecretorCM returns the env from sources of the secrets and cms.
+//Entries with an unknown type are left out and reported in the error
+func GetEnvfromSecretorCM(envFrom []kubernetes.EnvFrom) ([]corev1.EnvFromSource, error) {
 	if len(envFrom) > 0 && envFrom[0].Name != "" {
 		var envs []corev1.EnvFromSource
+		var invalid []string
 		for _, env := range envFrom {
 			switch env.Type {
-			case "Secret":
+			case kubernetes.EnvFromSecret:
 				envs = append(envs, corev1.EnvFromSource{
+					Prefix: env.Prefix,
 					SecretRef: &corev1.SecretEnvSource{
 						LocalObjectReference: corev1.LocalObjectReference{
 							Name: env.Name,
 						},
+						Optional: optional(env.Optional),
 					},
 				})
-			case "CM":
+			case kubernetes.EnvFromCM:
 				envs = append(envs, corev1.EnvFromSource{
+					Prefix: env.Prefix,
 					ConfigMapRef: &corev1.ConfigMapEnvSource{
 						LocalObjectReference: corev1.LocalObjectReference{
 							Name: env.Name,
 						},
+						Optional: optional(env.Optional),
 					},
 				})
+			default:
+				invalid = append(invalid, env.Name)
 			}
 		}
-		return envs
+		if len(invalid) > 0 {
+			return envs, fmt.Errorf(INVALID_ENV_FROM_TYPE_OF, strings.Join(invalid, ", "))
+		}
+		return envs, nil
 	}
-	return nil
+	return nil, nil
 }
 
 func GetEnvFromHTTPParam(queryParams map[string][]string) []corev1.EnvVar {
@@ -48,3 +76,59 @@ func GetEnvFromHTTPParam(queryParams map[string][]string) []corev1.EnvVar {
 	}
 	return nil
 }
+
+//GetEnvValueFrom returns the env variables for the secret and cm keys, pod fields and
+//container resources, entries without an env name are left out.
+//Entries with an unknown type are left out and reported in the error
+func GetEnvValueFrom(envValueFrom []kubernetes.EnvValueFrom) ([]corev1.EnvVar, error) {
+	var envVars []corev1.EnvVar
+	var invalid []string
+	for _, env := range envValueFrom {
+		if env.EnvName == "" {
+			continue
+		}
+		var source *corev1.EnvVarSource
+		switch env.Type {
+		case kubernetes.EnvValueFromSecret:
+			source = &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: env.Name},
+					Key:                  env.Key,
+					Optional:             optional(env.Optional),
+				},
+			}
+		case kubernetes.EnvValueFromCM:
+			source = &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: env.Name},
+					Key:                  env.Key,
+					Optional:             optional(env.Optional),
+				},
+			}
+		case kubernetes.EnvValueFromField:
+			source = &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: env.Key},
+			}
+		case kubernetes.EnvValueFromResource:
+			source = &corev1.EnvVarSource{
+				ResourceFieldRef: &corev1.ResourceFieldSelector{ContainerName: env.Name, Resource: env.Key},
+			}
+		default:
+			invalid = append(invalid, env.EnvName)
+			continue
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: env.EnvName, ValueFrom: source})
+	}
+	if len(invalid) > 0 {
+		return envVars, fmt.Errorf(INVALID_ENV_VALUE_FROM_TYPE, strings.Join(invalid, ", "))
+	}
+	return envVars, nil
+}
+
+//optional is only set when true, the api treats nil as false
+func optional(opt bool) *bool {
+	if opt {
+		return ptr.Bool(true)
+	}
+	return nil
+}
@@ -1,40 +1,63 @@
 package corev1
 
 import (
+	"fmt"
 	"testing"
 
 	"gotest.tools/assert"
 
 	corev1 "k8s.io/api/core/v1"
 
+	"knative.dev/pkg/ptr"
+
 	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
 	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
 )
 
 func TestEnvFromSecretorCM(t *testing.T) {
 	for _, tc := range []struct {
-		name  string
-		want  []corev1.EnvFromSource
-		input []kubernetes.EnvFrom
+		name    string
+		want    []corev1.EnvFromSource
+		wantErr string
+		input   []kubernetes.EnvFrom
 	}{{
 		name:  "test cm only - happy path",
 		want:  teststubcorev1.ConstructEnvFrom([]string{"cm1"}, []string{"CM"}),
-		input: []kubernetes.EnvFrom{{"cm1", "CM"}},
+		input: []kubernetes.EnvFrom{{Name: "cm1", Type: "CM"}},
 	}, {
 		name:  "test multiple cm without creating cm and expect error",
 		want:  teststubcorev1.ConstructEnvFrom([]string{"cm1", "cm2"}, []string{"CM", "CM"}),
-		input: []kubernetes.EnvFrom{{"cm1", "CM"}, {"cm2", "CM"}},
+		input: []kubernetes.EnvFrom{{Name: "cm1", Type: "CM"}, {Name: "cm2", Type: "CM"}},
 	}, {
 		name:  "test multiple secret w/o creating a secret and expect err",
 		want:  teststubcorev1.ConstructEnvFrom([]string{"s1", "s2"}, []string{"Secret", "Secret"}),
-		input: []kubernetes.EnvFrom{{"s1", "Secret"}, {"s2", "Secret"}},
+		input: []kubernetes.EnvFrom{{Name: "s1", Type: "Secret"}, {Name: "s2", Type: "Secret"}},
 	}, {
 		name:  "test multiple secret and cm without creating cm & secret - no error",
 		want:  teststubcorev1.ConstructEnvFrom([]string{"s1", "s2", "cm1", "cm2"}, []string{"Secret", "Secret", "CM", "CM"}),
-		input: []kubernetes.EnvFrom{{"s1", "Secret"}, {"s2", "Secret"}, {"cm1", "CM"}, {"cm2", "CM"}},
+		input: []kubernetes.EnvFrom{{Name: "s1", Type: "Secret"}, {Name: "s2", Type: "Secret"}, {Name: "cm1", Type: "CM"}, {Name: "cm2", Type: "CM"}},
+	}, {
+		name: "prefix and optional",
+		want: []corev1.EnvFromSource{{
+			Prefix:    "DB_",
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "s1"}},
+		}, {
+			ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"}, Optional: ptr.Bool(true)},
+		}},
+		input: []kubernetes.EnvFrom{{Name: "s1", Type: kubernetes.EnvFromSecret, Prefix: "DB_"}, {Name: "cm1", Type: kubernetes.EnvFromCM, Optional: true}},
+	}, {
+		name:    "unknown types are reported",
+		want:    teststubcorev1.ConstructEnvFrom([]string{"cm1"}, []string{"CM"}),
+		wantErr: fmt.Sprintf(INVALID_ENV_FROM_TYPE_OF, "cm2, s1"),
+		input:   []kubernetes.EnvFrom{{Name: "cm1", Type: "CM"}, {Name: "cm2", Type: "config"}, {Name: "s1", Type: "secret"}},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			actEnvFrom := GetEnvfromSecretorCM(tc.input)
+			actEnvFrom, err := GetEnvfromSecretorCM(tc.input)
+			if tc.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tc.wantErr)
+			}
 			assert.DeepEqual(t, &tc.want, &actEnvFrom)
 		})
 	}
@@ -0,0 +1,27 @@
+package corev1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+)
+
+//AddEphemeralContainer adds a debug container to the running pod, target is the container
+//whose process namespace is shared, empty target uses the pod namespaces.
+//Ephemeral containers can not be removed and need the EphemeralContainers feature gate
+func (c *coreClient) AddEphemeralContainer(ns, pod, target string, cspec kubernetes.ContainerSpec, options ...ContainerSpecOption) (*corev1.EphemeralContainers, error) {
+	container, err := GetContainerFromSpec(cspec, options...)
+	if err != nil {
+		return nil, err
+	}
+	ephemeralContainers, err := c.tcorev1.Pods(ns).GetEphemeralContainers(c.ctx, pod, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ephemeralContainers.EphemeralContainers = append(ephemeralContainers.EphemeralContainers, corev1.EphemeralContainer{
+		EphemeralContainerCommon: corev1.EphemeralContainerCommon(container),
+		TargetContainerName:      target,
+	})
+	return c.tcorev1.Pods(ns).UpdateEphemeralContainers(c.ctx, pod, ephemeralContainers, metav1.UpdateOptions{})
+}
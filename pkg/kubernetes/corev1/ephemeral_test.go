@@ -0,0 +1,55 @@
+package corev1
+
+import (
+	"context"
+	"testing"
+
+	"gotest.tools/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+func TestAddEphemeralContainer(t *testing.T) {
+	cs := testclient.NewSimpleClientset()
+	//the fake clientset serves the pod for the ephemeralcontainers subresource
+	var updated *corev1.EphemeralContainers
+	cs.PrependReactor("get", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "ephemeralcontainers" {
+			return false, nil, nil
+		}
+		return true, &corev1.EphemeralContainers{
+			EphemeralContainers: []corev1.EphemeralContainer{{
+				EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug-1", Image: "busybox"},
+			}},
+		}, nil
+	})
+	cs.PrependReactor("update", "pods", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		updated = action.(clienttesting.UpdateAction).GetObject().(*corev1.EphemeralContainers)
+		return true, updated, nil
+	})
+	c := &coreClient{tcorev1: cs.CoreV1(), ctx: context.Background()}
+
+	act, err := c.AddEphemeralContainer("ns", "foo", "app",
+		kubernetes.ContainerSpec{Name: "debug-2", Image: "busybox", Cmd: []string{"sh"}},
+		WithEnv([]corev1.EnvVar{{Name: "TERM", Value: "xterm"}}))
+	assert.NilError(t, err)
+	debug := teststubcorev1.ConstructExpectedContainerSpec(
+		teststubcorev1.WithName("debug-2"),
+		teststubcorev1.WithImage("busybox"),
+		teststubcorev1.WithCommand([]string{"sh"}),
+		teststubcorev1.WithEnv([]string{"TERM"}, []string{"xterm"}))
+	assert.DeepEqual(t, act, &corev1.EphemeralContainers{
+		EphemeralContainers: []corev1.EphemeralContainer{{
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug-1", Image: "busybox"},
+		}, {
+			EphemeralContainerCommon: corev1.EphemeralContainerCommon(debug),
+			TargetContainerName:      "app",
+		}},
+	})
+}
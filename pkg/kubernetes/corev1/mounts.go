@@ -1,7 +1,18 @@
 package corev1
 
 import (
+	"fmt"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+)
+
+const (
+	//VOLUME_CONFLICT - error message to indicate volumes share the name but not the source
+	VOLUME_CONFLICT = "volume %s is declared with different sources, set a distinct VolumeName"
 )
 
 func GetVolumeSources(configmaps []corev1.VolumeMount, secrets []corev1.VolumeMount) []corev1.Volume {
@@ -48,3 +59,105 @@ func GetVolumeMounts(configmaps []corev1.VolumeMount, secrets []corev1.VolumeMou
 	}
 	return mountList
 }
+
+//GetVolumes returns the cm and secret volumes of the declarations, the same volume
+//is added once. Declarations with an unknown type are left out and reported in the error,
+//declarations of one volume name with different sources return a conflict
+func GetVolumes(volumes []kubernetes.Volume) ([]corev1.Volume, error) {
+	var volList []corev1.Volume
+	var invalid []string
+	var conflict error
+	for _, volume := range volumes {
+		if volume.Name == "" {
+			continue
+		}
+		var source corev1.VolumeSource
+		switch volume.Type {
+		case kubernetes.VolumeCM:
+			source.ConfigMap = &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{
+					Name: volume.Name,
+				},
+				Items:       volume.Items,
+				DefaultMode: volume.DefaultMode,
+				Optional:    optional(volume.Optional),
+			}
+		case kubernetes.VolumeSecret:
+			source.Secret = &corev1.SecretVolumeSource{
+				SecretName:  volume.Name,
+				Items:       volume.Items,
+				DefaultMode: volume.DefaultMode,
+				Optional:    optional(volume.Optional),
+			}
+		default:
+			invalid = append(invalid, volume.Name)
+			continue
+		}
+		var err error
+		if volList, err = mergeVolumes(volList, corev1.Volume{Name: volumeName(volume), VolumeSource: source}); err != nil && conflict == nil {
+			conflict = err
+		}
+	}
+	if conflict != nil {
+		return volList, conflict
+	}
+	if len(invalid) > 0 {
+		return volList, fmt.Errorf(INVALID_VOLUME_TYPE, strings.Join(invalid, ", "))
+	}
+	return volList, nil
+}
+
+//GetMounts returns the mounts of the volume declarations, like GetVolumes
+//declarations with an unknown type are left out
+func GetMounts(volumes []kubernetes.Volume) []corev1.VolumeMount {
+	var mountList []corev1.VolumeMount
+	for _, volume := range volumes {
+		if volume.Name == "" || volume.MountPath == "" {
+			continue
+		}
+		if volume.Type != kubernetes.VolumeCM && volume.Type != kubernetes.VolumeSecret {
+			continue
+		}
+		mountList = append(mountList, corev1.VolumeMount{
+			Name:      volumeName(volume),
+			MountPath: volume.MountPath,
+			SubPath:   volume.SubPath,
+			ReadOnly:  volume.ReadOnly,
+		})
+	}
+	return mountList
+}
+
+func volumeName(volume kubernetes.Volume) string {
+	if volume.VolumeName != "" {
+		return volume.VolumeName
+	}
+	return volume.Name
+}
+
+//mergeVolumes appends the volumes which are not in the list yet, a volume with
+//the same name and source is added once. A volume with the name of a different
+//source is appended too, so the api server rejects the pod, and returns VOLUME_CONFLICT
+func mergeVolumes(volList []corev1.Volume, volumes ...corev1.Volume) ([]corev1.Volume, error) {
+	var conflicts []string
+	for _, vol := range volumes {
+		exists := false
+		for _, existing := range volList {
+			if existing.Name != vol.Name {
+				continue
+			}
+			if equality.Semantic.DeepEqual(existing.VolumeSource, vol.VolumeSource) {
+				exists = true
+				break
+			}
+			conflicts = append(conflicts, vol.Name)
+		}
+		if !exists {
+			volList = append(volList, vol)
+		}
+	}
+	if len(conflicts) > 0 {
+		return volList, fmt.Errorf(VOLUME_CONFLICT, strings.Join(conflicts, ", "))
+	}
+	return volList, nil
+}
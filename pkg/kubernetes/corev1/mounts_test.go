@@ -1,12 +1,16 @@
 package corev1
 
 import (
+	"fmt"
 	"testing"
 
 	"gotest.tools/assert"
 
 	corev1 "k8s.io/api/core/v1"
 
+	"knative.dev/pkg/ptr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
 	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
 )
 
@@ -65,3 +69,99 @@ func TestGetVolumeSourcesandMounts(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVolumesAndMounts(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		wantVolume      []corev1.Volume
+		wantVolumeMount []corev1.VolumeMount
+		wantErr         string
+		input           []kubernetes.Volume
+	}{{
+		name: "same secret at two paths",
+		wantVolume: []corev1.Volume{{
+			Name:         "s1",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "s1"}},
+		}},
+		wantVolumeMount: []corev1.VolumeMount{
+			{Name: "s1", MountPath: "/p1", ReadOnly: true},
+			{Name: "s1", MountPath: "/etc/app/token", SubPath: "token"},
+		},
+		input: []kubernetes.Volume{
+			{Name: "s1", Type: kubernetes.VolumeSecret, MountPath: "/p1", ReadOnly: true},
+			{Name: "s1", Type: kubernetes.VolumeSecret, MountPath: "/etc/app/token", SubPath: "token"},
+		},
+	}, {
+		name: "items, mode and optional",
+		wantVolume: []corev1.Volume{{
+			Name: "cm1",
+			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"},
+			}},
+		}, {
+			Name: "cm1-scripts",
+			VolumeSource: corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "cm1"},
+				Items:                []corev1.KeyToPath{{Key: "run.sh", Path: "bin/run.sh"}},
+				DefaultMode:          ptr.Int32(0755),
+				Optional:             ptr.Bool(true),
+			}},
+		}},
+		wantVolumeMount: []corev1.VolumeMount{
+			{Name: "cm1", MountPath: "/config"},
+			{Name: "cm1-scripts", MountPath: "/scripts"},
+		},
+		input: []kubernetes.Volume{
+			{Name: "cm1", Type: kubernetes.VolumeCM, MountPath: "/config"},
+			{
+				Name:        "cm1",
+				Type:        kubernetes.VolumeCM,
+				VolumeName:  "cm1-scripts",
+				MountPath:   "/scripts",
+				Items:       []corev1.KeyToPath{{Key: "run.sh", Path: "bin/run.sh"}},
+				DefaultMode: ptr.Int32(0755),
+				Optional:    true,
+			},
+		},
+	}, {
+		name: "same secret with different items",
+		wantVolume: []corev1.Volume{{
+			Name: "s1",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: "s1",
+				Items:      []corev1.KeyToPath{{Key: "token", Path: "token"}},
+			}},
+		}, {
+			Name: "s1",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{
+				SecretName: "s1",
+				Items:      []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+			}},
+		}},
+		wantVolumeMount: []corev1.VolumeMount{
+			{Name: "s1", MountPath: "/token"},
+			{Name: "s1", MountPath: "/ca"},
+		},
+		wantErr: fmt.Sprintf(VOLUME_CONFLICT, "s1"),
+		input: []kubernetes.Volume{
+			{Name: "s1", Type: kubernetes.VolumeSecret, MountPath: "/token", Items: []corev1.KeyToPath{{Key: "token", Path: "token"}}},
+			{Name: "s1", Type: kubernetes.VolumeSecret, MountPath: "/ca", Items: []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}}},
+		},
+	}, {
+		name:    "empty and unknown type",
+		wantErr: fmt.Sprintf(INVALID_VOLUME_TYPE, "pvc1"),
+		input:   []kubernetes.Volume{{MountPath: "/p1"}, {Name: "pvc1", Type: "PVC", MountPath: "/data"}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			actVol, err := GetVolumes(tc.input)
+			if tc.wantErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tc.wantErr)
+			}
+			assert.DeepEqual(t, &tc.wantVolume, &actVol)
+			actMt := GetMounts(tc.input)
+			assert.DeepEqual(t, &tc.wantVolumeMount, &actMt)
+		})
+	}
+}
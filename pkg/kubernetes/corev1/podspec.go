@@ -4,34 +4,106 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
 )
 
 type PodSpecOption func(*corev1.PodSpec)
 
+//GetPodSpec construct pod spec with the options and the init containers and sidecars declared
+//on the spec. Containers of the spec are added with WithContainerOptions, init containers of
+//the spec come before the ones of the options and sidecars are always added last. The cm and
+//secret volumes of the declared init containers and sidecars are added, ValidatePodSpec reports
+//the declarations which are left out or conflict
 func GetPodSpec(spec kubernetes.PodSpec, options ...PodSpecOption) corev1.PodSpec {
 	podSpec := corev1.PodSpec{}
 
+	for _, initContainer := range spec.InitContainers {
+		podSpec.InitContainers = append(podSpec.InitContainers, containerFromSpec(initContainer))
+	}
 	for _, fn := range options {
 		fn(&podSpec)
 	}
+	for _, sidecar := range spec.Sidecars {
+		podSpec.Containers = append(podSpec.Containers, containerFromSpec(sidecar))
+	}
+	//conflicting volumes are kept, the api server rejects the pod
+	volList, _ := containerVolumes(append(append([]kubernetes.ContainerSpec{}, spec.InitContainers...), spec.Sidecars...))
+	podSpec.Volumes, _ = mergeVolumes(podSpec.Volumes, volList...)
 	return podSpec
 }
 
+//ValidatePodSpec reports invalid containers declared on the spec and
+//volumes of the same name with different sources
+func ValidatePodSpec(spec kubernetes.PodSpec) error {
+	containers := declaredContainers(spec)
+	for _, container := range containers {
+		if err := ValidateContainerSpec(container); err != nil {
+			return err
+		}
+	}
+	_, err := containerVolumes(containers)
+	return err
+}
+
+func declaredContainers(spec kubernetes.PodSpec) []kubernetes.ContainerSpec {
+	var containers []kubernetes.ContainerSpec
+	containers = append(containers, spec.InitContainers...)
+	containers = append(containers, spec.Containers...)
+	return append(containers, spec.Sidecars...)
+}
+
 func WithContainerOptions(cspec kubernetes.ContainerSpec, options ...ContainerSpecOption) PodSpecOption {
 	return func(podSpec *corev1.PodSpec) {
 		podSpec.Containers = append(podSpec.Containers, GetContainerSpec(cspec, options...))
 	}
 }
 
+//WithInitContainerOptions - init containers run to completion in the order they are added
+func WithInitContainerOptions(cspec kubernetes.ContainerSpec, options ...ContainerSpecOption) PodSpecOption {
+	return func(podSpec *corev1.PodSpec) {
+		podSpec.InitContainers = append(podSpec.InitContainers, GetContainerSpec(cspec, options...))
+	}
+}
+
+//WithVolumes adds the cm and secret volumes of the containers, a volume
+//shared by containers or mounted at several paths is added once. Volumes of
+//the same name with different sources are kept for the api server to reject,
+//ValidatePodSpec and WithValidVolumes report them
 func WithVolumes(containers []kubernetes.ContainerSpec) PodSpecOption {
 	return func(spec *corev1.PodSpec) {
-		for _, container := range containers {
-			volList := GetVolumeSources(container.ConfigMaps, container.Secrets)
-			if len(volList) > 0 {
-				spec.Volumes = append(spec.Volumes, volList...)
-			}
+		volList, _ := containerVolumes(containers)
+		spec.Volumes, _ = mergeVolumes(spec.Volumes, volList...)
+	}
+}
+
+//WithValidVolumes adds the cm and secret volumes of the containers like WithVolumes,
+//volumes of the same name with different sources return VOLUME_CONFLICT
+func WithValidVolumes(containers []kubernetes.ContainerSpec) (PodSpecOption, error) {
+	volList, err := containerVolumes(containers)
+	if err != nil {
+		return nil, err
+	}
+	return func(spec *corev1.PodSpec) {
+		//a conflict with the volumes of other options is left to the api server
+		spec.Volumes, _ = mergeVolumes(spec.Volumes, volList...)
+	}, nil
+}
+
+//containerVolumes merges the volumes of all the containers and returns the first error
+func containerVolumes(containers []kubernetes.ContainerSpec) ([]corev1.Volume, error) {
+	var volList []corev1.Volume
+	var firstErr error
+	for _, container := range containers {
+		vols, err := GetVolumes(container.Volumes)
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		vols = append(GetVolumeSources(container.ConfigMaps, container.Secrets), vols...)
+		if volList, err = mergeVolumes(volList, vols...); err != nil && firstErr == nil {
+			firstErr = err
 		}
 	}
+	return volList, firstErr
 }
 
 func WithServiceAccount(sa string) PodSpecOption {
@@ -49,3 +121,19 @@ func WithRestartPolicy(policy string) PodSpecOption {
 		}
 	}
 }
+
+//WithNodeSelector - node labels the pod is scheduled on
+func WithNodeSelector(nodeSelector []kubernetes.KV) PodSpecOption {
+	return func(spec *corev1.PodSpec) {
+		spec.NodeSelector = transform.GetStringMap(nodeSelector, spec.NodeSelector)
+	}
+}
+
+//WithTolerations - taints the pod tolerates, like the ones on control plane nodes
+func WithTolerations(tolerations []corev1.Toleration) PodSpecOption {
+	return func(spec *corev1.PodSpec) {
+		if len(tolerations) > 0 {
+			spec.Tolerations = append(spec.Tolerations, tolerations...)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package corev1
 
 import (
+	"fmt"
 	"testing"
 
 	"gotest.tools/assert"
@@ -34,6 +35,8 @@ func TestGetPodSpec(t *testing.T) {
 			teststubcorev1.WithVolumes([]string{"c1"}, []string{"s1"}),
 			teststubcorev1.WithServiceAccount("admin-sa"),
 			teststubcorev1.WithRestartPolicy("Never"),
+			teststubcorev1.WithNodeSelector(map[string]string{"disktype": "ssd"}),
+			teststubcorev1.WithTolerations([]corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}),
 		),
 		inputModel: kubernetes.PodSpec{},
 		inputOptions: []PodSpecOption{
@@ -44,9 +47,11 @@ func TestGetPodSpec(t *testing.T) {
 				}}),
 			WithServiceAccount("admin-sa"),
 			WithRestartPolicy("Never"),
+			WithNodeSelector([]kubernetes.KV{{"disktype", "ssd"}}),
+			WithTolerations([]corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}}),
 			WithContainerOptions(kubernetes.ContainerSpec{Image: "docker.com/bar"},
 				WithEnv([]corev1.EnvVar{{Name: "e1", Value: "v1"}, {Name: "e2", Value: "v2"}}),
-				WithEnvFromSecretorCM([]kubernetes.EnvFrom{{"c1", "CM"}, {"s1", "Secret"}}),
+				WithEnvFromSecretorCM([]kubernetes.EnvFrom{{Name: "c1", Type: "CM"}, {Name: "s1", Type: "Secret"}}),
 				WithVolumeMounts(teststubcorev1.ConstructMounts([]string{"c1"}, []string{"/p1"}), teststubcorev1.ConstructMounts([]string{"s1"}, []string{"/p2"})),
 				WithPort(int32(8080)),
 				WithPort(int32(9090)),
@@ -64,9 +69,11 @@ func TestGetPodSpec(t *testing.T) {
 		),
 		inputModel: kubernetes.PodSpec{},
 		inputOptions: []PodSpecOption{
+			WithNodeSelector(nil),
+			WithTolerations(nil),
 			WithContainerOptions(kubernetes.ContainerSpec{Image: "docker.com/bar"},
 				WithEnv([]corev1.EnvVar{{Name: "", Value: ""}}),
-				WithEnvFromSecretorCM([]kubernetes.EnvFrom{{"", ""}}),
+				WithEnvFromSecretorCM([]kubernetes.EnvFrom{{Name: "", Type: ""}}),
 				WithVolumeMounts(teststubcorev1.ConstructMounts([]string{""}, []string{""}), teststubcorev1.ConstructMounts([]string{""}, []string{""})),
 				WithPort(int32(0)),
 				WithSecurityContext(int64(0)),
@@ -110,6 +117,84 @@ func TestGetPodSpec(t *testing.T) {
 				WithName("bar"),
 				WithPort(int32(9090))),
 		},
+	}, {
+		name: "Pod with shared volumes",
+		wantPodSpec: teststubcorev1.ConstructExpectedPodSpec(
+			teststubcorev1.WithVolumes([]string{"c1"}, []string{"s1"}),
+			teststubcorev1.WithVolumes([]string{"c2"}, nil),
+		),
+		inputModel: kubernetes.PodSpec{},
+		inputOptions: []PodSpecOption{
+			WithVolumes([]kubernetes.ContainerSpec{
+				{
+					Secrets:    teststubcorev1.ConstructMounts([]string{"s1"}, []string{"/p2"}),
+					ConfigMaps: teststubcorev1.ConstructMounts([]string{"c1"}, []string{"/p1"}),
+				},
+				{
+					ConfigMaps: teststubcorev1.ConstructMounts([]string{"c1", "c2"}, []string{"/p1", "/p3"}),
+					Volumes: []kubernetes.Volume{
+						{Name: "s1", Type: kubernetes.VolumeSecret, MountPath: "/p4", SubPath: "token"},
+						{Name: "c2", Type: kubernetes.VolumeCM, MountPath: "/p5"},
+					},
+				}}),
+		},
+	}, {
+		name: "Pod with init containers and sidecars",
+		wantPodSpec: teststubcorev1.ConstructExpectedPodSpec(
+			teststubcorev1.WithInitContainerOptions(
+				teststubcorev1.WithName("migrate"),
+				teststubcorev1.WithImage("docker.com/migrate"),
+				teststubcorev1.WithCommand([]string{"migrate", "up"}),
+				teststubcorev1.WithEnvFromSecretorCM([]string{"db"}, []string{"Secret"})),
+			teststubcorev1.WithInitContainerOptions(
+				teststubcorev1.WithName("wait"),
+				teststubcorev1.WithImage("docker.com/wait")),
+			teststubcorev1.WithContainerOptions(
+				teststubcorev1.WithName("web"),
+				teststubcorev1.WithImage("docker.com/web"),
+				teststubcorev1.WithVolumeMounts([]string{"tls"}, []string{"/tls"})),
+			teststubcorev1.WithContainerOptions(
+				teststubcorev1.WithName("app"),
+				teststubcorev1.WithImage("docker.com/app")),
+			teststubcorev1.WithContainerOptions(
+				teststubcorev1.WithName("proxy"),
+				teststubcorev1.WithImage("docker.com/proxy")),
+			teststubcorev1.WithContainerOptions(
+				teststubcorev1.WithName("log-shipper"),
+				teststubcorev1.WithImage("docker.com/shipper"),
+				teststubcorev1.WithVolumeMounts([]string{"logs"}, []string{"/var/log/app"})),
+			teststubcorev1.WithVolumes(nil, []string{"tls"}),
+			teststubcorev1.WithVolumes([]string{"logs"}, nil),
+		),
+		inputModel: kubernetes.PodSpec{
+			InitContainers: []kubernetes.ContainerSpec{{
+				Name:              "migrate",
+				Image:             "docker.com/migrate",
+				Cmd:               []string{"migrate", "up"},
+				EnvFromSecretorCM: []kubernetes.EnvFrom{{Name: "db", Type: "Secret"}},
+			}},
+			Containers: []kubernetes.ContainerSpec{{
+				Name:    "web",
+				Image:   "docker.com/web",
+				Secrets: teststubcorev1.ConstructMounts([]string{"tls"}, []string{"/tls"}),
+			}},
+			Sidecars: []kubernetes.ContainerSpec{{
+				Name:  "proxy",
+				Image: "docker.com/proxy",
+			}, {
+				Name:       "log-shipper",
+				Image:      "docker.com/shipper",
+				ConfigMaps: teststubcorev1.ConstructMounts([]string{"logs"}, []string{"/var/log/app"}),
+			}},
+		},
+		inputOptions: []PodSpecOption{
+			WithInitContainerOptions(kubernetes.ContainerSpec{Image: "docker.com/wait"}, WithName("wait")),
+			WithContainerOptions(kubernetes.ContainerSpec{Image: "docker.com/web"},
+				WithName("web"),
+				WithVolumeMounts(nil, teststubcorev1.ConstructMounts([]string{"tls"}, []string{"/tls"}))),
+			WithVolumes([]kubernetes.ContainerSpec{{Secrets: teststubcorev1.ConstructMounts([]string{"tls"}, []string{"/tls"})}}),
+			WithContainerOptions(kubernetes.ContainerSpec{Image: "docker.com/app"}, WithName("app")),
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			actPod := GetPodSpec(tc.inputModel, tc.inputOptions...)
@@ -117,3 +202,78 @@ func TestGetPodSpec(t *testing.T) {
 		})
 	}
 }
+
+func TestWithValidVolumes(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		want  string
+		input []kubernetes.ContainerSpec
+	}{{
+		name: "cm and secret of the same name",
+		want: fmt.Sprintf(VOLUME_CONFLICT, "app"),
+		input: []kubernetes.ContainerSpec{
+			{ConfigMaps: teststubcorev1.ConstructMounts([]string{"app"}, []string{"/config"})},
+			{Secrets: teststubcorev1.ConstructMounts([]string{"app"}, []string{"/secret"})},
+		},
+	}, {
+		name: "same secret with different items",
+		want: fmt.Sprintf(VOLUME_CONFLICT, "s1"),
+		input: []kubernetes.ContainerSpec{{
+			Volumes: []kubernetes.Volume{
+				{Name: "s1", Type: kubernetes.VolumeSecret, MountPath: "/p1", Items: []corev1.KeyToPath{{Key: "token", Path: "token"}}},
+				{Name: "s1", Type: kubernetes.VolumeSecret, MountPath: "/p2", Items: []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}}},
+			},
+		}},
+	}, {
+		name: "same secret in two containers",
+		input: []kubernetes.ContainerSpec{
+			{Secrets: teststubcorev1.ConstructMounts([]string{"s1"}, []string{"/p1"})},
+			{Volumes: []kubernetes.Volume{{Name: "s1", Type: kubernetes.VolumeSecret, MountPath: "/p2"}}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := WithValidVolumes(tc.input)
+			if tc.want == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidatePodSpec(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		want  string
+		input kubernetes.PodSpec
+	}{{
+		name: "valid spec",
+		input: kubernetes.PodSpec{
+			InitContainers: []kubernetes.ContainerSpec{{Secrets: teststubcorev1.ConstructMounts([]string{"db"}, []string{"/db"})}},
+			Containers:     []kubernetes.ContainerSpec{{Secrets: teststubcorev1.ConstructMounts([]string{"db"}, []string{"/etc/db"})}},
+		},
+	}, {
+		name: "invalid sidecar",
+		want: fmt.Sprintf(INVALID_ENV_FROM_TYPE_OF, "logs"),
+		input: kubernetes.PodSpec{
+			Sidecars: []kubernetes.ContainerSpec{{EnvFromSecretorCM: []kubernetes.EnvFrom{{Name: "logs", Type: "cm"}}}},
+		},
+	}, {
+		name: "volume conflict between containers",
+		want: fmt.Sprintf(VOLUME_CONFLICT, "app"),
+		input: kubernetes.PodSpec{
+			Containers: []kubernetes.ContainerSpec{{ConfigMaps: teststubcorev1.ConstructMounts([]string{"app"}, []string{"/config"})}},
+			Sidecars:   []kubernetes.ContainerSpec{{Secrets: teststubcorev1.ConstructMounts([]string{"app"}, []string{"/secret"})}},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidatePodSpec(tc.input)
+			if tc.want == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package corev1
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+)
+
+type ProbeOption func(*corev1.Probe)
+
+const (
+	//INVALID_PROBE_TYPE - error message to indicate unknown probe type
+	INVALID_PROBE_TYPE = "Provide a valid probe type, got %q. Should be 'Liveness', 'Readiness' or 'Startup'"
+	//INVALID_PROBE_HANDLER - error message to indicate unknown probe handler
+	INVALID_PROBE_HANDLER = "Provide a valid probe handler, got %q. Should be 'HTTP', 'TCP', 'Exec' or 'GRPC'"
+	//INVALID_GRPC_PORT - error message to indicate the grpc probe port is not a number
+	INVALID_GRPC_PORT = "grpc probe port should be a port number, got %q"
+)
+
+//grpcHealthProbe - binary implementing the grpc health checking protocol,
+//core/v1 in client-go v0.18 has no grpc probe action
+const grpcHealthProbe = "grpc_health_probe"
+
+//GetProbe construct probe spec based on option provided
+func GetProbe(options ...ProbeOption) *corev1.Probe {
+	probe := &corev1.Probe{}
+	for _, fn := range options {
+		fn(probe)
+	}
+	return probe
+}
+
+//WithLivenessProbe - container is restarted when the probe fails
+func WithLivenessProbe(options ...ProbeOption) ContainerSpecOption {
+	return func(container *corev1.Container) {
+		if len(options) > 0 {
+			container.LivenessProbe = GetProbe(options...)
+		}
+	}
+}
+
+//WithReadinessProbe - pod is removed from the service endpoints while the probe fails
+func WithReadinessProbe(options ...ProbeOption) ContainerSpecOption {
+	return func(container *corev1.Container) {
+		if len(options) > 0 {
+			container.ReadinessProbe = GetProbe(options...)
+		}
+	}
+}
+
+//WithStartupProbe - liveness and readiness probes start once the probe succeeds
+func WithStartupProbe(options ...ProbeOption) ContainerSpecOption {
+	return func(container *corev1.Container) {
+		if len(options) > 0 {
+			container.StartupProbe = GetProbe(options...)
+		}
+	}
+}
+
+//WithProbes sets the probes declared on the container spec, probes with an
+//unknown type or handler are left out, ValidateProbes reports them
+func WithProbes(probes []kubernetes.Probe) ContainerSpecOption {
+	return func(container *corev1.Container) {
+		for _, probe := range probes {
+			options, err := probeOptions(probe)
+			if err != nil {
+				continue
+			}
+			switch probe.Type {
+			case "Liveness":
+				WithLivenessProbe(options...)(container)
+			case "Readiness":
+				WithReadinessProbe(options...)(container)
+			case "Startup":
+				WithStartupProbe(options...)(container)
+			}
+		}
+	}
+}
+
+//ValidateProbes reports unknown probe types and handlers and named grpc ports
+func ValidateProbes(probes []kubernetes.Probe) error {
+	for _, probe := range probes {
+		switch probe.Type {
+		case "Liveness", "Readiness", "Startup":
+		default:
+			return fmt.Errorf(INVALID_PROBE_TYPE, probe.Type)
+		}
+		if _, err := probeOptions(probe); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func probeOptions(probe kubernetes.Probe) ([]ProbeOption, error) {
+	var options []ProbeOption
+	switch probe.Handler {
+	case "HTTP":
+		options = append(options, WithHTTPGet(probe.Path, probe.Port))
+	case "TCP":
+		options = append(options, WithTCPSocket(probe.Port))
+	case "Exec":
+		options = append(options, WithExec(probe.Command))
+	case "GRPC":
+		grpc, err := WithGRPC(probe.Port, probe.Path)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, grpc)
+	default:
+		return nil, fmt.Errorf(INVALID_PROBE_HANDLER, probe.Handler)
+	}
+	return append(options,
+		WithProbeTimings(probe.InitialDelay, probe.Period, probe.Timeout),
+		WithProbeThresholds(probe.SuccessThreshold, probe.FailureThreshold)), nil
+}
+
+//WithHTTPGet - probe succeeds on a 2xx or 3xx response, port is a number or a container port name
+func WithHTTPGet(path, port string) ProbeOption {
+	return func(probe *corev1.Probe) {
+		probe.Handler = corev1.Handler{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: path,
+				Port: intstr.Parse(port),
+			},
+		}
+	}
+}
+
+//WithTCPSocket - probe succeeds when the port accepts the connection
+func WithTCPSocket(port string) ProbeOption {
+	return func(probe *corev1.Probe) {
+		probe.Handler = corev1.Handler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: intstr.Parse(port),
+			},
+		}
+	}
+}
+
+//WithExec - probe succeeds when the command exits with 0
+func WithExec(cmd []string) ProbeOption {
+	return func(probe *corev1.Probe) {
+		if len(cmd) > 0 && cmd[0] != "" {
+			probe.Handler = corev1.Handler{
+				Exec: &corev1.ExecAction{Command: cmd},
+			}
+		}
+	}
+}
+
+//WithGRPC - grpc health check of the service, empty service checks the server.
+//client-go v0.18 has no grpc probe action so grpc_health_probe is run with exec,
+//the binary has to be in the image. The port has to be a number, the probe runs
+//in the container and can not resolve container port names
+func WithGRPC(port, service string) (ProbeOption, error) {
+	if p, err := strconv.Atoi(port); err != nil || p <= 0 || p > 65535 {
+		return nil, fmt.Errorf(INVALID_GRPC_PORT, port)
+	}
+	cmd := []string{grpcHealthProbe, fmt.Sprintf("-addr=:%s", port)}
+	if service != "" {
+		cmd = append(cmd, fmt.Sprintf("-service=%s", service))
+	}
+	return WithExec(cmd), nil
+}
+
+//WithProbeTimings - seconds before the first probe, between probes and till a probe times out,
+//0 leaves the kubernetes default
+func WithProbeTimings(initialDelay, period, timeout int32) ProbeOption {
+	return func(probe *corev1.Probe) {
+		if initialDelay > int32(0) {
+			probe.InitialDelaySeconds = initialDelay
+		}
+		if period > int32(0) {
+			probe.PeriodSeconds = period
+		}
+		if timeout > int32(0) {
+			probe.TimeoutSeconds = timeout
+		}
+	}
+}
+
+//WithProbeThresholds - consecutive successes and failures which change the probe result,
+//0 leaves the kubernetes default
+func WithProbeThresholds(success, failure int32) ProbeOption {
+	return func(probe *corev1.Probe) {
+		if success > int32(0) {
+			probe.SuccessThreshold = success
+		}
+		if failure > int32(0) {
+			probe.FailureThreshold = failure
+		}
+	}
+}
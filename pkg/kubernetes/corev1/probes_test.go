@@ -0,0 +1,145 @@
+package corev1
+
+import (
+	"fmt"
+	"testing"
+
+	"gotest.tools/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+var (
+	httpProbe = &corev1.Probe{
+		Handler:             corev1.Handler{HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromString("http")}},
+		InitialDelaySeconds: int32(5),
+		PeriodSeconds:       int32(10),
+		TimeoutSeconds:      int32(2),
+		FailureThreshold:    int32(3),
+	}
+	tcpProbe = &corev1.Probe{
+		Handler:          corev1.Handler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(5432)}},
+		SuccessThreshold: int32(2),
+	}
+	execProbe = &corev1.Probe{
+		Handler:          corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"cat", "/tmp/started"}}},
+		FailureThreshold: int32(30),
+		PeriodSeconds:    int32(10),
+	}
+	grpcProbe = &corev1.Probe{
+		Handler: corev1.Handler{Exec: &corev1.ExecAction{Command: []string{"grpc_health_probe", "-addr=:9000", "-service=orders"}}},
+	}
+)
+
+func TestProbeOptions(t *testing.T) {
+	grpc, err := WithGRPC("9000", "orders")
+	assert.NilError(t, err)
+
+	for _, tc := range []struct {
+		name          string
+		wantContainer corev1.Container
+		inputModel    kubernetes.ContainerSpec
+		inputOptions  []ContainerSpecOption
+	}{{
+		name: "probes from options",
+		wantContainer: teststubcorev1.ConstructExpectedContainerSpec(
+			teststubcorev1.WithImage("app"),
+			teststubcorev1.WithLivenessProbe(httpProbe),
+			teststubcorev1.WithReadinessProbe(tcpProbe),
+			teststubcorev1.WithStartupProbe(execProbe)),
+		inputModel: kubernetes.ContainerSpec{Image: "app"},
+		inputOptions: []ContainerSpecOption{
+			WithLivenessProbe(WithHTTPGet("/healthz", "http"), WithProbeTimings(int32(5), int32(10), int32(2)), WithProbeThresholds(int32(0), int32(3))),
+			WithReadinessProbe(WithTCPSocket("5432"), WithProbeThresholds(int32(2), int32(0))),
+			WithStartupProbe(WithExec([]string{"cat", "/tmp/started"}), WithProbeTimings(int32(0), int32(10), int32(0)), WithProbeThresholds(int32(0), int32(30))),
+		},
+	}, {
+		name: "grpc probe",
+		wantContainer: teststubcorev1.ConstructExpectedContainerSpec(
+			teststubcorev1.WithImage("app"),
+			teststubcorev1.WithReadinessProbe(grpcProbe)),
+		inputModel: kubernetes.ContainerSpec{Image: "app"},
+		inputOptions: []ContainerSpecOption{
+			WithReadinessProbe(grpc),
+		},
+	}, {
+		name: "probes declared on the spec",
+		wantContainer: teststubcorev1.ConstructExpectedContainerSpec(
+			teststubcorev1.WithImage("app"),
+			teststubcorev1.WithLivenessProbe(httpProbe),
+			teststubcorev1.WithReadinessProbe(tcpProbe),
+			teststubcorev1.WithStartupProbe(execProbe)),
+		inputModel: kubernetes.ContainerSpec{Image: "app", Probes: []kubernetes.Probe{
+			{Type: "Liveness", Handler: "HTTP", Path: "/healthz", Port: "http", InitialDelay: int32(5), Period: int32(10), Timeout: int32(2), FailureThreshold: int32(3)},
+			{Type: "Readiness", Handler: "TCP", Port: "5432", SuccessThreshold: int32(2)},
+			{Type: "Startup", Handler: "Exec", Command: []string{"cat", "/tmp/started"}, Period: int32(10), FailureThreshold: int32(30)},
+		}},
+	}, {
+		name: "option overrides declared probe",
+		wantContainer: teststubcorev1.ConstructExpectedContainerSpec(
+			teststubcorev1.WithImage("app"),
+			teststubcorev1.WithReadinessProbe(grpcProbe)),
+		inputModel: kubernetes.ContainerSpec{Image: "app", Probes: []kubernetes.Probe{
+			{Type: "Readiness", Handler: "TCP", Port: "9000"},
+		}},
+		inputOptions: []ContainerSpecOption{
+			WithReadinessProbe(grpc),
+		},
+	}, {
+		name: "probes with null values",
+		wantContainer: teststubcorev1.ConstructExpectedContainerSpec(
+			teststubcorev1.WithImage("app")),
+		inputModel: kubernetes.ContainerSpec{Image: "app", Probes: []kubernetes.Probe{
+			{Type: "Liveness", Handler: "Unknown"},
+			{Type: "Unknown", Handler: "TCP", Port: "80"},
+		}},
+		inputOptions: []ContainerSpecOption{
+			WithLivenessProbe(),
+			WithProbes(nil),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			actContainer := GetContainerSpec(tc.inputModel, tc.inputOptions...)
+			assert.DeepEqual(t, &tc.wantContainer, &actContainer)
+		})
+	}
+}
+
+func TestValidateProbes(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		want  string
+		input []kubernetes.Probe
+	}{{
+		name: "valid probes",
+		input: []kubernetes.Probe{
+			{Type: "Liveness", Handler: "HTTP", Path: "/healthz", Port: "http"},
+			{Type: "Readiness", Handler: "GRPC", Port: "9000"},
+		},
+	}, {
+		name:  "unknown type",
+		want:  fmt.Sprintf(INVALID_PROBE_TYPE, "liveness"),
+		input: []kubernetes.Probe{{Type: "liveness", Handler: "HTTP", Path: "/healthz", Port: "http"}},
+	}, {
+		name:  "unknown handler",
+		want:  fmt.Sprintf(INVALID_PROBE_HANDLER, "Http"),
+		input: []kubernetes.Probe{{Type: "Liveness", Handler: "Http", Path: "/healthz", Port: "http"}},
+	}, {
+		name:  "named grpc port",
+		want:  fmt.Sprintf(INVALID_GRPC_PORT, "grpc"),
+		input: []kubernetes.Probe{{Type: "Readiness", Handler: "GRPC", Port: "grpc"}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateProbes(tc.input)
+			if tc.want == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tc.want)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	types "github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
@@ -16,6 +17,16 @@ const (
 	SECRET_MISSING = "secret %s is not found in the namespace %s, create secret before referring in the function"
 	//INVALID_ENV_FROM_TYPE - error message to indicate invalid type to mount as env
 	INVALID_ENV_FROM_TYPE = "Provide a valid EnvFrom type. Should be 'CM' or 'Secret'"
+	//INVALID_ENV_FROM_TYPE_OF - error message to indicate invalid type of the named env from sources
+	INVALID_ENV_FROM_TYPE_OF = "Provide a valid EnvFrom type for %s. Should be 'CM' or 'Secret'"
+	//INVALID_VOLUME_TYPE - error message to indicate invalid type of the volume
+	INVALID_VOLUME_TYPE = "Provide a valid volume type for %s. Should be 'CM' or 'Secret'"
+	//INVALID_ENV_VALUE_FROM_TYPE - error message to indicate invalid type of env value source
+	INVALID_ENV_VALUE_FROM_TYPE = "Provide a valid EnvValueFrom type for %s. Should be 'CM', 'Secret', 'Field' or 'Resource'"
+	//CM_KEY_MISSING - error message to indicate the key is missing in the CM
+	CM_KEY_MISSING = "key %s is not found in cm %s in the namespace %s"
+	//SECRET_KEY_MISSING - error message to indicate the key is missing in the Secret
+	SECRET_KEY_MISSING = "key %s is not found in secret %s in the namespace %s"
 )
 
 func (c *coreClient) CheckIfCMExist(nsName string, cm string) bool {
@@ -64,25 +75,89 @@ func (c *coreClient) CheckCMMounts(ns string, cms []corev1.VolumeMount) error {
 	return nil
 }
 
-func (c *coreClient) CheckEnvFromResources(ns string, envsFrom []types.EnvFrom) error {
+//CheckVolumes checks the cms and secrets of the volume declarations exist unless they are optional
+func (c *coreClient) CheckVolumes(ns string, volumes []types.Volume) error {
+	for _, volume := range volumes {
+		if volume.Name == "" || volume.Optional {
+			continue
+		}
+		switch volume.Type {
+		case types.VolumeCM:
+			if !c.CheckIfCMExist(ns, volume.Name) {
+				return fmt.Errorf(CM_MISSING, volume.Name, ns)
+			}
+		case types.VolumeSecret:
+			if !c.CheckIfSecretExist(ns, volume.Name) {
+				return fmt.Errorf(SECRET_MISSING, volume.Name, ns)
+			}
+		default:
+			return fmt.Errorf(INVALID_VOLUME_TYPE, volume.Name)
+		}
+	}
+	return nil
+}
+
+//CheckEnvFromResources checks the secrets and cms referred as env exist unless they are optional,
+//for the single key references the key has to exist too unless it is optional
+func (c *coreClient) CheckEnvFromResources(ns string, envsFrom []types.EnvFrom, envValuesFrom ...types.EnvValueFrom) error {
 	// check env from
 	for _, env := range envsFrom {
 		if env.Name == "" {
 			continue
 		}
 		switch env.Type {
-		case "CM":
-			if !c.CheckIfCMExist(ns, env.Name) {
+		case types.EnvFromCM:
+			if !env.Optional && !c.CheckIfCMExist(ns, env.Name) {
 				return fmt.Errorf(CM_MISSING, env.Name, ns)
 			}
-		case "Secret":
-			if !c.CheckIfSecretExist(ns, env.Name) {
+		case types.EnvFromSecret:
+			if !env.Optional && !c.CheckIfSecretExist(ns, env.Name) {
 				return fmt.Errorf(SECRET_MISSING, env.Name, ns)
 			}
 		default:
 			return fmt.Errorf(INVALID_ENV_FROM_TYPE)
 		}
 	}
+	return c.checkEnvValueFrom(ns, envValuesFrom)
+}
+
+func (c *coreClient) checkEnvValueFrom(ns string, envValuesFrom []types.EnvValueFrom) error {
+	for _, env := range envValuesFrom {
+		if env.EnvName == "" {
+			continue
+		}
+		switch env.Type {
+		case types.EnvValueFromCM:
+			cm, err := c.tcorev1.ConfigMaps(ns).Get(c.ctx, env.Name, metav1.GetOptions{})
+			if err != nil {
+				if env.Optional && apierrs.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf(CM_MISSING, env.Name, ns)
+			}
+			_, inData := cm.Data[env.Key]
+			_, inBinaryData := cm.BinaryData[env.Key]
+			if !inData && !inBinaryData && !env.Optional {
+				return fmt.Errorf(CM_KEY_MISSING, env.Key, env.Name, ns)
+			}
+		case types.EnvValueFromSecret:
+			secret, err := c.tcorev1.Secrets(ns).Get(c.ctx, env.Name, metav1.GetOptions{})
+			if err != nil {
+				if env.Optional && apierrs.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf(SECRET_MISSING, env.Name, ns)
+			}
+			_, inData := secret.Data[env.Key]
+			_, inStringData := secret.StringData[env.Key]
+			if !inData && !inStringData && !env.Optional {
+				return fmt.Errorf(SECRET_KEY_MISSING, env.Key, env.Name, ns)
+			}
+		case types.EnvValueFromField, types.EnvValueFromResource:
+		default:
+			return fmt.Errorf(INVALID_ENV_VALUE_FROM_TYPE, env.EnvName)
+		}
+	}
 	return nil
 }
 
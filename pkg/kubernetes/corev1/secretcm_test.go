@@ -190,18 +190,59 @@ func TestCheckSecretMounts(t *testing.T) {
 	}
 }
 
+func TestCheckVolumes(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		want           string
+		input          []types.Volume
+		runtimeObjects []runtime.Object
+	}{{
+		name: "cm and secret created",
+		want: "",
+		input: []types.Volume{
+			{Name: "cm1", Type: types.VolumeCM, MountPath: "/p1"},
+			{Name: "s1", Type: types.VolumeSecret, VolumeName: "token", MountPath: "/p2"},
+		},
+		runtimeObjects: []runtime.Object{
+			teststubcorev1.ConstructConfigMap("foo", "cm1"),
+			teststubcorev1.ConstructSecret("foo", "s1"),
+		},
+	}, {
+		name: "secret missing",
+		want: fmt.Sprintf(SECRET_MISSING, "s1", "foo"),
+		input: []types.Volume{
+			{Name: "cm1", Type: types.VolumeCM, MountPath: "/p1", Optional: true},
+			{Name: "s1", Type: types.VolumeSecret, MountPath: "/p2"},
+		},
+	}, {
+		name:  "wrong type",
+		want:  fmt.Sprintf(INVALID_VOLUME_TYPE, "pvc1"),
+		input: []types.Volume{{Name: "pvc1", Type: "PVC", MountPath: "/p1"}},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := (&coreClient{tcorev1: testclient.NewSimpleClientset((tc.runtimeObjects)...).CoreV1()}).CheckVolumes("foo", tc.input)
+			if tc.want == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Error(t, err, tc.want)
+			}
+		})
+	}
+}
+
 func TestEnvFromResources(t *testing.T) {
 	for _, tc := range []struct {
 		name           string
 		want           string
 		ns             string
 		input          []types.EnvFrom
+		valuesFrom     []types.EnvValueFrom
 		runtimeObjects []runtime.Object
 	}{{
 		name:  "1 cm 2 secret",
 		want:  "",
 		ns:    "foo",
-		input: []types.EnvFrom{{"c1", "CM"}, {"s1", "Secret"}, {"s2", "Secret"}},
+		input: []types.EnvFrom{{Name: "c1", Type: "CM"}, {Name: "s1", Type: "Secret"}, {Name: "s2", Type: "Secret"}},
 		runtimeObjects: []runtime.Object{
 			teststubcorev1.ConstructConfigMap("foo", "c1"),
 			teststubcorev1.ConstructSecret("foo", "s1"),
@@ -211,7 +252,7 @@ func TestEnvFromResources(t *testing.T) {
 		name:  "cm missing",
 		want:  fmt.Sprintf(CM_MISSING, "c1", "foo"),
 		ns:    "foo",
-		input: []types.EnvFrom{{"s1", "Secret"}, {"c1", "CM"}},
+		input: []types.EnvFrom{{Name: "s1", Type: "Secret"}, {Name: "c1", Type: "CM"}},
 		runtimeObjects: []runtime.Object{
 			teststubcorev1.ConstructSecret("foo", "s1"),
 		},
@@ -219,22 +260,90 @@ func TestEnvFromResources(t *testing.T) {
 		name:  "secret missing",
 		want:  fmt.Sprintf(SECRET_MISSING, "s1", "foo"),
 		ns:    "foo",
-		input: []types.EnvFrom{{"cm1", "CM"}, {"s1", "Secret"}},
+		input: []types.EnvFrom{{Name: "cm1", Type: "CM"}, {Name: "s1", Type: "Secret"}},
 		runtimeObjects: []runtime.Object{
 			teststubcorev1.ConstructConfigMap("foo", "cm1"),
 		},
 	}, {
 		name:  "wrong type",
 		want:  fmt.Sprintf(INVALID_ENV_FROM_TYPE),
-		input: []types.EnvFrom{{"c1", "CM"}, {"s2", "Secret"}, {"cm2", "config"}},
+		input: []types.EnvFrom{{Name: "c1", Type: "CM"}, {Name: "s2", Type: "Secret"}, {Name: "cm2", Type: "config"}},
 		ns:    "foo",
 		runtimeObjects: []runtime.Object{
 			teststubcorev1.ConstructConfigMap("foo", "c1"),
 			teststubcorev1.ConstructSecret("foo", "s2"),
 		},
+	}, {
+		name:  "optional cm missing",
+		want:  "",
+		ns:    "foo",
+		input: []types.EnvFrom{{Name: "s1", Type: types.EnvFromSecret}, {Name: "c1", Type: types.EnvFromCM, Optional: true}},
+		runtimeObjects: []runtime.Object{
+			teststubcorev1.ConstructSecret("foo", "s1"),
+		},
+	}, {
+		name:  "keys exist",
+		want:  "",
+		ns:    "foo",
+		input: []types.EnvFrom{{Name: "s1", Type: "Secret"}},
+		valuesFrom: []types.EnvValueFrom{
+			{EnvName: "TOKEN", Type: "Secret", Name: "s1", Key: "token"},
+			{EnvName: "LEVEL", Type: "CM", Name: "c1", Key: "level"},
+			{EnvName: "POD_IP", Type: "Field", Key: "status.podIP"},
+			{EnvName: "MEM", Type: "Resource", Key: "limits.memory"},
+		},
+		runtimeObjects: []runtime.Object{
+			teststubcorev1.GetConfigMap("foo", "c1", teststubcorev1.WithConfigMapData(map[string]string{"level": "debug"})),
+			teststubcorev1.ConstructSecret("foo", "s1"),
+		},
+	}, {
+		name: "secret key missing",
+		want: fmt.Sprintf(SECRET_KEY_MISSING, "password", "s1", "foo"),
+		ns:   "foo",
+		valuesFrom: []types.EnvValueFrom{
+			{EnvName: "PASSWORD", Type: "Secret", Name: "s1", Key: "password"},
+		},
+		runtimeObjects: []runtime.Object{
+			teststubcorev1.ConstructSecret("foo", "s1"),
+		},
+	}, {
+		name: "cm key missing",
+		want: fmt.Sprintf(CM_KEY_MISSING, "level", "c1", "foo"),
+		ns:   "foo",
+		valuesFrom: []types.EnvValueFrom{
+			{EnvName: "LEVEL", Type: "CM", Name: "c1", Key: "level"},
+		},
+		runtimeObjects: []runtime.Object{
+			teststubcorev1.ConstructConfigMap("foo", "c1"),
+		},
+	}, {
+		name: "cm of key missing",
+		want: fmt.Sprintf(CM_MISSING, "c1", "foo"),
+		ns:   "foo",
+		valuesFrom: []types.EnvValueFrom{
+			{EnvName: "LEVEL", Type: "CM", Name: "c1", Key: "level"},
+		},
+	}, {
+		name: "optional keys missing",
+		want: "",
+		ns:   "foo",
+		valuesFrom: []types.EnvValueFrom{
+			{EnvName: "PASSWORD", Type: "Secret", Name: "s1", Key: "password", Optional: true},
+			{EnvName: "LEVEL", Type: "CM", Name: "c1", Key: "level", Optional: true},
+		},
+		runtimeObjects: []runtime.Object{
+			teststubcorev1.ConstructSecret("foo", "s1"),
+		},
+	}, {
+		name: "wrong value from type",
+		want: fmt.Sprintf(INVALID_ENV_VALUE_FROM_TYPE, "LEVEL"),
+		ns:   "foo",
+		valuesFrom: []types.EnvValueFrom{
+			{EnvName: "LEVEL", Type: "config", Name: "c1", Key: "level"},
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
-			err := (&coreClient{tcorev1: testclient.NewSimpleClientset((tc.runtimeObjects)...).CoreV1()}).CheckEnvFromResources(tc.ns, tc.input)
+			err := (&coreClient{tcorev1: testclient.NewSimpleClientset((tc.runtimeObjects)...).CoreV1()}).CheckEnvFromResources(tc.ns, tc.input, tc.valuesFrom...)
 			if tc.want == "" {
 				assert.NilError(t, err)
 			} else {
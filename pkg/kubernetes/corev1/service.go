@@ -0,0 +1,136 @@
+package corev1
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/transform"
+)
+
+type ServiceOption func(*corev1.Service)
+
+//GetService construct service spec based on option provided
+func GetService(name string, options ...ServiceOption) corev1.Service {
+	service := corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		}}
+
+	for _, fn := range options {
+		fn(&service)
+	}
+	return service
+}
+
+//WithServiceType - ClusterIP, NodePort or LoadBalancer
+func WithServiceType(serviceType corev1.ServiceType) ServiceOption {
+	return func(service *corev1.Service) {
+		if serviceType != "" {
+			service.Spec.Type = serviceType
+		}
+	}
+}
+
+//WithHeadless - no cluster ip, dns resolves to the pod ips, used by stateful sets
+func WithHeadless() ServiceOption {
+	return func(service *corev1.Service) {
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+	}
+}
+
+//WithSelector - labels of the pods the service routes to
+func WithSelector(selector []kubernetes.KV) ServiceOption {
+	return func(service *corev1.Service) {
+		service.Spec.Selector = transform.GetStringMap(selector, service.Spec.Selector)
+	}
+}
+
+//WithPortsFromContainers adds a tcp port for every container with a port, targeting the
+//container port by the name PortName gives it, containers built from the spec name their
+//port that way. A port number already on the service is not added again, the first
+//container declaring it is targeted
+func WithPortsFromContainers(specs []kubernetes.ContainerSpec) ServiceOption {
+	return func(service *corev1.Service) {
+		for _, spec := range specs {
+			if spec.Port <= 0 || hasServicePort(spec.Port, service.Spec.Ports) {
+				continue
+			}
+			name := PortName(spec)
+			service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+				Name:       uniquePortName(name, spec.Port, service.Spec.Ports),
+				Protocol:   corev1.ProtocolTCP,
+				Port:       spec.Port,
+				TargetPort: intstr.FromString(name),
+			})
+		}
+	}
+}
+
+//hasServicePort checks for a tcp service port with the port number,
+//the api server rejects a service with the same port and protocol twice
+func hasServicePort(port int32, ports []corev1.ServicePort) bool {
+	for _, p := range ports {
+		if p.Port == port && (p.Protocol == "" || p.Protocol == corev1.ProtocolTCP) {
+			return true
+		}
+	}
+	return false
+}
+
+//uniquePortName falls back to port-<port> when the name is taken by another
+//service port, and numbers the name when that is taken too
+func uniquePortName(name string, port int32, ports []corev1.ServicePort) string {
+	taken := func(name string) bool {
+		for _, p := range ports {
+			if p.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+	if !taken(name) {
+		return name
+	}
+	base := fmt.Sprintf("port-%d", port)
+	name = base
+	for i := 2; taken(name); i++ {
+		suffix := fmt.Sprintf("-%d", i)
+		if len(base)+len(suffix) > 15 {
+			base = base[:15-len(suffix)]
+		}
+		name = base + suffix
+	}
+	return name
+}
+
+//PortName returns the port name for the container, the container name cut to the
+//15 characters allowed for port names, or port-<port> when the name can not be used
+func PortName(spec kubernetes.ContainerSpec) string {
+	name := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		}
+		return '-'
+	}, spec.Name)
+	if len(name) > 15 {
+		name = name[:15]
+	}
+	name = strings.Trim(name, "-")
+	if strings.IndexFunc(name, func(r rune) bool { return r >= 'a' && r <= 'z' }) < 0 || strings.Contains(name, "--") {
+		return fmt.Sprintf("port-%d", spec.Port)
+	}
+	return name
+}
@@ -0,0 +1,117 @@
+package corev1
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/kubernetes"
+	teststubcorev1 "github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+func TestGetService(t *testing.T) {
+	containers := []kubernetes.ContainerSpec{
+		{Name: "web", Port: int32(8080)},
+		{Name: "log-shipper"},
+		{Name: "Metrics_Exporter_Sidecar", Port: int32(9090)},
+		{Port: int32(5000)},
+	}
+	for _, tc := range []struct {
+		name         string
+		want         corev1.Service
+		inputOptions []ServiceOption
+	}{{
+		name: "service with ports from containers",
+		want: teststubcorev1.ConstructExpectedService(
+			teststubcorev1.WithServiceType(corev1.ServiceTypeLoadBalancer),
+			teststubcorev1.WithSelector(map[string]string{"app": "foo"}),
+			teststubcorev1.WithServicePort("web", int32(8080)),
+			teststubcorev1.WithServicePort("metrics-exporte", int32(9090)),
+			teststubcorev1.WithServicePort("port-5000", int32(5000))),
+		inputOptions: []ServiceOption{
+			WithServiceType(corev1.ServiceTypeLoadBalancer),
+			WithSelector([]kubernetes.KV{{"app", "foo"}}),
+			WithPortsFromContainers(containers),
+		},
+	}, {
+		name: "port names cut to the same name",
+		want: teststubcorev1.ConstructExpectedService(
+			teststubcorev1.WithServiceType(corev1.ServiceTypeClusterIP),
+			teststubcorev1.WithServicePort("metrics-exporte", int32(9090)),
+			teststubcorev1.WithServicePortTarget("port-9091", int32(9091), "metrics-exporte"),
+			teststubcorev1.WithServicePort("port-9092", int32(9093)),
+			teststubcorev1.WithServicePortTarget("port-9092-2", int32(9092), "metrics-exporte")),
+		inputOptions: []ServiceOption{
+			WithServiceType(corev1.ServiceTypeClusterIP),
+			WithPortsFromContainers([]kubernetes.ContainerSpec{
+				{Name: "metrics-exporter-a", Port: int32(9090)},
+				{Name: "metrics-exporter-b", Port: int32(9091)},
+			}),
+			WithPortsFromContainers([]kubernetes.ContainerSpec{
+				{Name: "port-9092", Port: int32(9093)},
+				{Name: "metrics-exporter-c", Port: int32(9092)},
+			}),
+		},
+	}, {
+		name: "port declared by two containers",
+		want: teststubcorev1.ConstructExpectedService(
+			teststubcorev1.WithServiceType(corev1.ServiceTypeClusterIP),
+			teststubcorev1.WithServicePort("web", int32(8080)),
+			teststubcorev1.WithServicePort("admin", int32(9000))),
+		inputOptions: []ServiceOption{
+			WithServiceType(corev1.ServiceTypeClusterIP),
+			WithPortsFromContainers([]kubernetes.ContainerSpec{
+				{Name: "web", Port: int32(8080)},
+				{Name: "web-canary", Port: int32(8080)},
+			}),
+			WithPortsFromContainers([]kubernetes.ContainerSpec{
+				{Name: "web", Port: int32(8080)},
+				{Name: "admin", Port: int32(9000)},
+			}),
+		},
+	}, {
+		name: "headless service",
+		want: teststubcorev1.ConstructExpectedService(
+			teststubcorev1.WithServiceType(corev1.ServiceTypeClusterIP),
+			teststubcorev1.WithClusterIP("None"),
+			teststubcorev1.WithSelector(map[string]string{"app": "db"}),
+			teststubcorev1.WithServicePort("db", int32(5432))),
+		inputOptions: []ServiceOption{
+			WithHeadless(),
+			WithSelector([]kubernetes.KV{{"app", "db"}}),
+			WithPortsFromContainers([]kubernetes.ContainerSpec{{Name: "db", Port: int32(5432)}}),
+		},
+	}, {
+		name: "service with null options",
+		want: teststubcorev1.ConstructExpectedService(),
+		inputOptions: []ServiceOption{
+			WithServiceType(""),
+			WithSelector(nil),
+			WithPortsFromContainers(nil),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			act := GetService("foo", tc.inputOptions...)
+			assert.DeepEqual(t, &tc.want, &act)
+		})
+	}
+}
+
+func TestPortName(t *testing.T) {
+	for _, tc := range []struct {
+		spec kubernetes.ContainerSpec
+		want string
+	}{
+		{kubernetes.ContainerSpec{Name: "http", Port: int32(80)}, "http"},
+		{kubernetes.ContainerSpec{Name: "Metrics_Exporter_Sidecar", Port: int32(9090)}, "metrics-exporte"},
+		{kubernetes.ContainerSpec{Name: "12345", Port: int32(9090)}, "port-9090"},
+		{kubernetes.ContainerSpec{Name: "a__b", Port: int32(9090)}, "port-9090"},
+		{kubernetes.ContainerSpec{Port: int32(80)}, "port-80"},
+	} {
+		t.Run(tc.want, func(t *testing.T) {
+			assert.Equal(t, PortName(tc.spec), tc.want)
+		})
+	}
+}
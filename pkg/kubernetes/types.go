@@ -4,10 +4,63 @@ import (
 	corev1 "k8s.io/api/core/v1"
 )
 
+//EnvFromType - kind of the source of env variables
+type EnvFromType string
+
+const (
+	//EnvFromCM - env variables from a config map
+	EnvFromCM EnvFromType = "CM"
+	//EnvFromSecret - env variables from a secret
+	EnvFromSecret EnvFromType = "Secret"
+)
+
+//EnvValueFromType - kind of the source of a single env variable
+type EnvValueFromType string
+
+const (
+	//EnvValueFromCM - env variable from a config map key
+	EnvValueFromCM EnvValueFromType = EnvValueFromType(EnvFromCM)
+	//EnvValueFromSecret - env variable from a secret key
+	EnvValueFromSecret EnvValueFromType = EnvValueFromType(EnvFromSecret)
+	//EnvValueFromField - env variable from a pod field
+	EnvValueFromField EnvValueFromType = "Field"
+	//EnvValueFromResource - env variable from a container resource
+	EnvValueFromResource EnvValueFromType = "Resource"
+)
+
 //EnvFrom = env variables
+//Prefix is prepended to every key, Optional allows the cm or secret to be missing
 type EnvFrom struct {
-	Name string
-	Type string
+	Name     string
+	Type     EnvFromType
+	Prefix   string
+	Optional bool
+}
+
+//VolumeType - kind of the object mounted as volume
+type VolumeType string
+
+const (
+	//VolumeCM - config map volume
+	VolumeCM VolumeType = "CM"
+	//VolumeSecret - secret volume
+	VolumeSecret VolumeType = "Secret"
+)
+
+//Volume - cm or secret mounted in the container.
+//Name is the cm or secret, VolumeName defaults to it and has to be set to mount the
+//same object with different items or mode. Items project the keys to relative paths,
+//only the listed keys are mounted then. Optional allows the object or keys to be missing
+type Volume struct {
+	Name        string
+	Type        VolumeType
+	VolumeName  string
+	MountPath   string
+	SubPath     string
+	ReadOnly    bool
+	Items       []corev1.KeyToPath
+	DefaultMode *int32
+	Optional    bool
 }
 
 //ContainerSpec - kubernetes core/v1/container
@@ -18,16 +71,52 @@ type ContainerSpec struct {
 	Resources         []Resource
 	Secrets           []corev1.VolumeMount
 	ConfigMaps        []corev1.VolumeMount
+	Volumes           []Volume
 	EnvVariables      []corev1.EnvVar
 	User              int64
 	EnvFromSecretorCM []EnvFrom
+	EnvValueFrom      []EnvValueFrom
 	Cmd               []string
 	ServiceAccount    string
+	Probes            []Probe
+}
+
+//EnvValueFrom - env variable from a single key of a secret or cm, a pod field or a container resource.
+//Type is EnvValueFromSecret, EnvValueFromCM, EnvValueFromField or EnvValueFromResource. For Secret and CM Name and Key select the key, optional
+//allows the secret, cm or key to be missing. For Field Key is the field path like metadata.namespace,
+//for Resource Key is the resource like limits.cpu and Name the container
+type EnvValueFrom struct {
+	EnvName  string
+	Type     EnvValueFromType
+	Name     string
+	Key      string
+	Optional bool
+}
+
+//Probe - container health check
+//Type is Liveness, Readiness or Startup and Handler is HTTP, TCP, Exec or GRPC.
+//Port is a number or a container port name, GRPC only takes a number. Path is used by HTTP, Command by Exec
+//and for GRPC Path is the service name
+type Probe struct {
+	Type             string
+	Handler          string
+	Path             string
+	Port             string
+	Command          []string
+	InitialDelay     int32
+	Period           int32
+	Timeout          int32
+	SuccessThreshold int32
+	FailureThreshold int32
 }
 
-//ContainerSpec - kubernetes core/v1/pod
+//PodSpec - kubernetes core/v1/pod
+//Containers are added with WithContainerOptions, InitContainers run in order before
+//the containers, Sidecars are kept in order and always added after the containers
 type PodSpec struct {
-	Containers []ContainerSpec
+	Containers     []ContainerSpec
+	InitContainers []ContainerSpec
+	Sidecars       []ContainerSpec
 }
 
 //JobSpec - kubernetes batch/v1/job
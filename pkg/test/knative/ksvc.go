@@ -0,0 +1,162 @@
+package knative
+
+import (
+	corev1api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/ptr"
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+type expectedKServiceOption func(*servingv1.Service)
+
+func ConstructExpectedKService(options ...expectedKServiceOption) servingv1.Service {
+	ksvc := servingv1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "serving.knative.dev/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		}}
+
+	for _, fn := range options {
+		fn(&ksvc)
+	}
+	return ksvc
+}
+
+func WithPodSpecOptions(options ...corev1.ExpectedPodSpecOption) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Template.Spec.PodSpec = corev1.ConstructExpectedPodSpec(options...)
+	}
+}
+
+func WithAnnotations(annotations map[string]string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Template.ObjectMeta.Annotations = annotations
+	}
+}
+
+func WithLabels(labels map[string]string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Template.ObjectMeta.Labels = labels
+	}
+}
+
+func WithOwnerReference(ownerRef metav1.OwnerReference) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.ObjectMeta.OwnerReferences = []metav1.OwnerReference{ownerRef}
+	}
+}
+
+func WithContainerConcurrency(concurrency int64) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Template.Spec.ContainerConcurrency = ptr.Int64(concurrency)
+	}
+}
+
+func WithTimeout(timeout int64) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Template.Spec.TimeoutSeconds = ptr.Int64(timeout)
+	}
+}
+
+func WithNamespace(ns string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.ObjectMeta.Namespace = ns
+	}
+}
+
+func WithResourceVersion(version string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.ObjectMeta.ResourceVersion = version
+	}
+}
+
+func WithServiceLabels(labels map[string]string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.ObjectMeta.Labels = labels
+	}
+}
+
+func WithURL(url string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Status.URL, _ = apis.ParseURL(url)
+	}
+}
+
+func WithCondition(t apis.ConditionType, status corev1api.ConditionStatus, reason, message string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Status.SetConditions(append(ksvc.Status.GetConditions(), apis.Condition{
+			Type:    t,
+			Status:  status,
+			Reason:  reason,
+			Message: message,
+		}))
+	}
+}
+
+func WithGeneration(generation, observedGeneration int64) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.ObjectMeta.Generation = generation
+		ksvc.Status.ObservedGeneration = observedGeneration
+	}
+}
+
+func WithRevisionName(name string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Template.ObjectMeta.Name = name
+	}
+}
+
+func WithTrafficTarget(tag, revisionName string, latest bool, percent int64) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Spec.Traffic = append(ksvc.Spec.Traffic, servingv1.TrafficTarget{
+			Tag:            tag,
+			RevisionName:   revisionName,
+			LatestRevision: ptr.Bool(latest),
+			Percent:        ptr.Int64(percent),
+		})
+	}
+}
+
+func WithStatusTraffic(tag, revisionName string, percent int64) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Status.Traffic = append(ksvc.Status.Traffic, servingv1.TrafficTarget{
+			Tag:          tag,
+			RevisionName: revisionName,
+			Percent:      ptr.Int64(percent),
+		})
+	}
+}
+
+func WithLatestRevisions(created, ready string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		ksvc.Status.LatestCreatedRevisionName = created
+		ksvc.Status.LatestReadyRevisionName = ready
+	}
+}
+
+func WithAddress(url string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		address, _ := apis.ParseURL(url)
+		ksvc.Status.Address = &duckv1.Addressable{URL: address}
+	}
+}
+
+func WithTagURL(tag, revisionName, url string) expectedKServiceOption {
+	return func(ksvc *servingv1.Service) {
+		tagURL, _ := apis.ParseURL(url)
+		ksvc.Status.Traffic = append(ksvc.Status.Traffic, servingv1.TrafficTarget{
+			Tag:          tag,
+			RevisionName: revisionName,
+			Percent:      ptr.Int64(0),
+			URL:          tagURL,
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package knative
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	servingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+func ConstructRevision(ns, service, name string, age time.Duration) *servingv1.Revision {
+	return &servingv1.Revision{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Revision",
+			APIVersion: "serving.knative.dev/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         ns,
+			Labels:            map[string]string{"serving.knative.dev/service": service},
+			CreationTimestamp: metav1.NewTime(time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC).Add(-age)),
+		},
+	}
+}
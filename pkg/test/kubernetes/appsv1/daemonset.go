@@ -0,0 +1,70 @@
+package appsv1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+type expectedDaemonSetOption func(*appsv1.DaemonSet)
+
+func ConstructExpectedDaemonSet(options ...expectedDaemonSetOption) appsv1.DaemonSet {
+	daemonSet := appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DaemonSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		}}
+
+	for _, fn := range options {
+		fn(&daemonSet)
+	}
+	return daemonSet
+}
+
+func WithDaemonSetPodSpecOptions(options ...corev1.ExpectedPodSpecOption) expectedDaemonSetOption {
+	return func(daemonSet *appsv1.DaemonSet) {
+		daemonSet.Spec.Template.Spec = corev1.ConstructExpectedPodSpec(options...)
+	}
+}
+
+func WithDaemonSetNamespace(ns string) expectedDaemonSetOption {
+	return func(daemonSet *appsv1.DaemonSet) {
+		daemonSet.Namespace = ns
+	}
+}
+
+func WithDaemonSetLabels(labels map[string]string) expectedDaemonSetOption {
+	return func(daemonSet *appsv1.DaemonSet) {
+		daemonSet.Spec.Template.ObjectMeta.Labels = labels
+		selector := make(map[string]string)
+		for k, v := range labels {
+			selector[k] = v
+		}
+		daemonSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	}
+}
+
+func WithDaemonSetUpdateStrategy(strategy appsv1.DaemonSetUpdateStrategyType, maxUnavailable *intstr.IntOrString) expectedDaemonSetOption {
+	return func(daemonSet *appsv1.DaemonSet) {
+		daemonSet.Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{Type: strategy}
+		if maxUnavailable != nil {
+			daemonSet.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateDaemonSet{MaxUnavailable: maxUnavailable}
+		}
+	}
+}
+
+//WithDaemonSetStatus - generations and pod counts reported by the controller
+func WithDaemonSetStatus(generation, observed int64, desired, updated, available int32) expectedDaemonSetOption {
+	return func(daemonSet *appsv1.DaemonSet) {
+		daemonSet.Generation = generation
+		daemonSet.Status.ObservedGeneration = observed
+		daemonSet.Status.DesiredNumberScheduled = desired
+		daemonSet.Status.UpdatedNumberScheduled = updated
+		daemonSet.Status.NumberAvailable = available
+	}
+}
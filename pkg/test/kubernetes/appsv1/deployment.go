@@ -0,0 +1,123 @@
+package appsv1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1api "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"knative.dev/pkg/ptr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+type expectedDeploymentOption func(*appsv1.Deployment)
+
+func ConstructExpectedDeployment(options ...expectedDeploymentOption) appsv1.Deployment {
+	deployment := appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		}}
+
+	for _, fn := range options {
+		fn(&deployment)
+	}
+	return deployment
+}
+
+func WithPodSpecOptions(options ...corev1.ExpectedPodSpecOption) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.Template.Spec = corev1.ConstructExpectedPodSpec(options...)
+	}
+}
+
+func WithNamespace(ns string) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Namespace = ns
+	}
+}
+
+func WithReplicas(replicas int32) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.Replicas = ptr.Int32(replicas)
+	}
+}
+
+func WithLabels(labels map[string]string) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.Template.ObjectMeta.Labels = labels
+		selector := make(map[string]string)
+		for k, v := range labels {
+			selector[k] = v
+		}
+		deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	}
+}
+
+func WithDeploymentLabels(labels map[string]string) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Labels = labels
+	}
+}
+
+func WithRollingUpdate(maxSurge, maxUnavailable intstr.IntOrString) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.Strategy = appsv1.DeploymentStrategy{
+			Type: appsv1.RollingUpdateDeploymentStrategyType,
+			RollingUpdate: &appsv1.RollingUpdateDeployment{
+				MaxSurge:       &maxSurge,
+				MaxUnavailable: &maxUnavailable,
+			},
+		}
+	}
+}
+
+func WithRecreate() expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}
+	}
+}
+
+func WithRevisionHistoryLimit(limit int32) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.RevisionHistoryLimit = ptr.Int32(limit)
+	}
+}
+
+func WithProgressDeadline(seconds int32) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Spec.ProgressDeadlineSeconds = ptr.Int32(seconds)
+	}
+}
+
+//WithGeneration - generation of the spec and the generation observed by the controller
+func WithGeneration(generation, observed int64) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Generation = generation
+		deployment.Status.ObservedGeneration = observed
+	}
+}
+
+func WithReplicaStatus(replicas, updated, ready, available int32) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Status.Replicas = replicas
+		deployment.Status.UpdatedReplicas = updated
+		deployment.Status.ReadyReplicas = ready
+		deployment.Status.AvailableReplicas = available
+	}
+}
+
+func WithProgressingCondition(reason, message string) expectedDeploymentOption {
+	return func(deployment *appsv1.Deployment) {
+		deployment.Status.Conditions = append(deployment.Status.Conditions, appsv1.DeploymentCondition{
+			Type:    appsv1.DeploymentProgressing,
+			Status:  corev1api.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package appsv1
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1api "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/ptr"
+
+	"github.com/itsmurugappan/kubernetes-resource-builder/pkg/test/kubernetes/corev1"
+)
+
+type expectedStatefulSetOption func(*appsv1.StatefulSet)
+
+func ConstructExpectedStatefulSet(options ...expectedStatefulSetOption) appsv1.StatefulSet {
+	statefulSet := appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		}}
+
+	for _, fn := range options {
+		fn(&statefulSet)
+	}
+	return statefulSet
+}
+
+func WithStatefulSetPodSpecOptions(options ...corev1.ExpectedPodSpecOption) expectedStatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.Template.Spec = corev1.ConstructExpectedPodSpec(options...)
+	}
+}
+
+func WithStatefulSetNamespace(ns string) expectedStatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Namespace = ns
+	}
+}
+
+func WithStatefulSetReplicas(replicas int32) expectedStatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.Replicas = ptr.Int32(replicas)
+	}
+}
+
+func WithStatefulSetLabels(labels map[string]string) expectedStatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.Template.ObjectMeta.Labels = labels
+		selector := make(map[string]string)
+		for k, v := range labels {
+			selector[k] = v
+		}
+		statefulSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	}
+}
+
+func WithServiceName(serviceName string) expectedStatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.ServiceName = serviceName
+	}
+}
+
+func WithPodManagementPolicy(policy appsv1.PodManagementPolicyType) expectedStatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.PodManagementPolicy = policy
+	}
+}
+
+func WithStatefulSetUpdateStrategy(strategy appsv1.StatefulSetUpdateStrategyType, partition *int32) expectedStatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.UpdateStrategy = appsv1.StatefulSetUpdateStrategy{Type: strategy}
+		if partition != nil {
+			statefulSet.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: partition}
+		}
+	}
+}
+
+func WithVolumeClaimTemplate(name, size string, storageClass *string, accessModes ...corev1api.PersistentVolumeAccessMode) expectedStatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Spec.VolumeClaimTemplates = append(statefulSet.Spec.VolumeClaimTemplates, corev1api.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1api.PersistentVolumeClaimSpec{
+				AccessModes: accessModes,
+				Resources: corev1api.ResourceRequirements{
+					Requests: corev1api.ResourceList{corev1api.ResourceStorage: resource.MustParse(size)},
+				},
+				StorageClassName: storageClass,
+			},
+		})
+	}
+}
+
+//WithStatefulSetStatus - generations, replica counts and revisions reported by the controller
+func WithStatefulSetStatus(generation, observed int64, updated, ready int32, currentRevision, updateRevision string) expectedStatefulSetOption {
+	return func(statefulSet *appsv1.StatefulSet) {
+		statefulSet.Generation = generation
+		statefulSet.Status.ObservedGeneration = observed
+		statefulSet.Status.UpdatedReplicas = updated
+		statefulSet.Status.ReadyReplicas = ready
+		statefulSet.Status.CurrentRevision = currentRevision
+		statefulSet.Status.UpdateRevision = updateRevision
+	}
+}
@@ -1,7 +1,10 @@
 package batchv1
 
 import (
+	"time"
+
 	batchv1 "k8s.io/api/batch/v1"
+	corev1api "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"knative.dev/pkg/ptr"
@@ -64,3 +67,73 @@ func WithActiveStatus() expectedJobSpecOption {
 		job.Status.Active = int32(1)
 	}
 }
+
+func WithNamespace(ns string) expectedJobSpecOption {
+	return func(job *batchv1.Job) {
+		job.ObjectMeta.Namespace = ns
+	}
+}
+
+func WithCondition(conditionType batchv1.JobConditionType, reason, message string) expectedJobSpecOption {
+	return func(job *batchv1.Job) {
+		job.Status.Conditions = append(job.Status.Conditions, batchv1.JobCondition{
+			Type:    conditionType,
+			Status:  corev1api.ConditionTrue,
+			Reason:  reason,
+			Message: message,
+		})
+	}
+}
+
+func WithPodCounts(active, succeeded, failed int32) expectedJobSpecOption {
+	return func(job *batchv1.Job) {
+		job.Status.Active = active
+		job.Status.Succeeded = succeeded
+		job.Status.Failed = failed
+	}
+}
+
+func WithCompletions(completions int32) expectedJobSpecOption {
+	return func(job *batchv1.Job) {
+		job.Spec.Completions = ptr.Int32(completions)
+	}
+}
+
+func WithActiveDeadline(seconds int64) expectedJobSpecOption {
+	return func(job *batchv1.Job) {
+		job.Spec.ActiveDeadlineSeconds = ptr.Int64(seconds)
+	}
+}
+
+func WithManualSelector(selector map[string]string) expectedJobSpecOption {
+	return func(job *batchv1.Job) {
+		job.Spec.ManualSelector = ptr.Bool(true)
+		job.Spec.Selector = &metav1.LabelSelector{MatchLabels: selector}
+	}
+}
+
+//WithGeneratedSelector - selector and labels the job controller sets on a job
+func WithGeneratedSelector(uid string) expectedJobSpecOption {
+	return func(job *batchv1.Job) {
+		controllerLabels := map[string]string{"controller-uid": uid, "job-name": job.Name}
+		job.Spec.Selector = &metav1.LabelSelector{MatchLabels: map[string]string{"controller-uid": uid}}
+		job.ObjectMeta.Labels = controllerLabels
+		if job.Spec.Template.ObjectMeta.Labels == nil {
+			job.Spec.Template.ObjectMeta.Labels = make(map[string]string)
+		}
+		for k, v := range controllerLabels {
+			job.Spec.Template.ObjectMeta.Labels[k] = v
+		}
+	}
+}
+
+func WithTimes(start time.Time, completion time.Time) expectedJobSpecOption {
+	return func(job *batchv1.Job) {
+		if !start.IsZero() {
+			job.Status.StartTime = &metav1.Time{Time: start}
+		}
+		if !completion.IsZero() {
+			job.Status.CompletionTime = &metav1.Time{Time: completion}
+		}
+	}
+}
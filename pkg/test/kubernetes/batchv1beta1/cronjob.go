@@ -0,0 +1,85 @@
+package batchv1beta1
+
+import (
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"knative.dev/pkg/ptr"
+)
+
+type expectedCronJobOption func(*batchv1beta1.CronJob)
+
+func ConstructExpectedCronJob(options ...expectedCronJobOption) batchv1beta1.CronJob {
+	cronJob := batchv1beta1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CronJob",
+			APIVersion: "batch/v1beta1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		}}
+
+	for _, fn := range options {
+		fn(&cronJob)
+	}
+	return cronJob
+}
+
+//WithJobTemplate - template from the expected job, see test/kubernetes/batchv1
+func WithJobTemplate(job batchv1.Job) expectedCronJobOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.Spec.JobTemplate.ObjectMeta.Labels = job.ObjectMeta.Labels
+		cronJob.Spec.JobTemplate.ObjectMeta.Annotations = job.ObjectMeta.Annotations
+		cronJob.Spec.JobTemplate.Spec = job.Spec
+	}
+}
+
+func WithNamespace(ns string) expectedCronJobOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.Namespace = ns
+	}
+}
+
+func WithSchedule(schedule string) expectedCronJobOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.Spec.Schedule = schedule
+	}
+}
+
+func WithConcurrencyPolicy(policy batchv1beta1.ConcurrencyPolicy) expectedCronJobOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.Spec.ConcurrencyPolicy = policy
+	}
+}
+
+func WithSuspend(suspend bool) expectedCronJobOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.Spec.Suspend = ptr.Bool(suspend)
+	}
+}
+
+func WithStartingDeadline(seconds int64) expectedCronJobOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.Spec.StartingDeadlineSeconds = ptr.Int64(seconds)
+	}
+}
+
+func WithHistoryLimits(successful, failed int32) expectedCronJobOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.Spec.SuccessfulJobsHistoryLimit = ptr.Int32(successful)
+		cronJob.Spec.FailedJobsHistoryLimit = ptr.Int32(failed)
+	}
+}
+
+func WithLabels(labels map[string]string) expectedCronJobOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.Labels = labels
+	}
+}
+
+func WithOwnerReference(ownerRef metav1.OwnerReference) expectedCronJobOption {
+	return func(cronJob *batchv1beta1.CronJob) {
+		cronJob.ObjectMeta.OwnerReferences = []metav1.OwnerReference{ownerRef}
+	}
+}
@@ -38,6 +38,63 @@ func WithEnv(keys []string, vals []string) expectedContainerOption {
 	}
 }
 
+func WithFieldRefEnv(name, fieldPath string) expectedContainerOption {
+	return func(container *corev1.Container) {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{
+					FieldPath: fieldPath,
+				},
+			},
+		})
+	}
+}
+
+func WithSecretKeyEnv(name, secret, key string, optional *bool) expectedContainerOption {
+	return func(container *corev1.Container) {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secret},
+					Key:                  key,
+					Optional:             optional,
+				},
+			},
+		})
+	}
+}
+
+func WithConfigMapKeyEnv(name, cm, key string, optional *bool) expectedContainerOption {
+	return func(container *corev1.Container) {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: cm},
+					Key:                  key,
+					Optional:             optional,
+				},
+			},
+		})
+	}
+}
+
+func WithResourceFieldRefEnv(name, containerName, resource string) expectedContainerOption {
+	return func(container *corev1.Container) {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				ResourceFieldRef: &corev1.ResourceFieldSelector{
+					ContainerName: containerName,
+					Resource:      resource,
+				},
+			},
+		})
+	}
+}
+
 func WithEnvFromSecretorCM(names []string, types []string) expectedContainerOption {
 	return func(container *corev1.Container) {
 		container.EnvFrom = ConstructEnvFrom(names, types)
@@ -58,6 +115,15 @@ func WithPort(port int32) expectedContainerOption {
 	}
 }
 
+func WithNamedPort(name string, port int32) expectedContainerOption {
+	return func(container *corev1.Container) {
+		container.Ports = append(container.Ports, corev1.ContainerPort{
+			Name:          name,
+			ContainerPort: port,
+		})
+	}
+}
+
 func WithSecurityContext(user int64) expectedContainerOption {
 	return func(container *corev1.Container) {
 		container.SecurityContext = &corev1.SecurityContext{
@@ -88,6 +154,31 @@ func WithImagePullPolicy(pullPolicy corev1.PullPolicy) expectedContainerOption {
 	}
 }
 
+func WithTerminationMessage(path string, policy corev1.TerminationMessagePolicy) expectedContainerOption {
+	return func(container *corev1.Container) {
+		container.TerminationMessagePath = path
+		container.TerminationMessagePolicy = policy
+	}
+}
+
+func WithLivenessProbe(probe *corev1.Probe) expectedContainerOption {
+	return func(container *corev1.Container) {
+		container.LivenessProbe = probe
+	}
+}
+
+func WithReadinessProbe(probe *corev1.Probe) expectedContainerOption {
+	return func(container *corev1.Container) {
+		container.ReadinessProbe = probe
+	}
+}
+
+func WithStartupProbe(probe *corev1.Probe) expectedContainerOption {
+	return func(container *corev1.Container) {
+		container.StartupProbe = probe
+	}
+}
+
 func WithResources(cpuReq int64, cpuLim int64, memReq int64, memLim int64) expectedContainerOption {
 	return func(container *corev1.Container) {
 		resReq := corev1.ResourceRequirements{}
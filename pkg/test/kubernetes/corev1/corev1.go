@@ -68,6 +68,24 @@ func ConstructConfigMap(ns string, name string) *corev1.ConfigMap {
 	}
 }
 
+type expectedConfigMapOption func(*corev1.ConfigMap)
+
+func GetConfigMap(ns, name string, options ...expectedConfigMapOption) *corev1.ConfigMap {
+	cm := ConstructConfigMap(ns, name)
+
+	for _, fn := range options {
+		fn(cm)
+	}
+
+	return cm
+}
+
+func WithConfigMapData(data map[string]string) expectedConfigMapOption {
+	return func(cm *corev1.ConfigMap) {
+		cm.Data = data
+	}
+}
+
 func ConstructMounts(names []string, paths []string) []corev1.VolumeMount {
 	var mounts []corev1.VolumeMount
 	for i, name := range names {
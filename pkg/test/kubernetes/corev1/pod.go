@@ -0,0 +1,71 @@
+package corev1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type expectedPodOption func(*corev1.Pod)
+
+func ConstructPod(ns, name string, options ...expectedPodOption) *corev1.Pod {
+	pod := &corev1.Pod{
+		TypeMeta:   metav1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns},
+	}
+
+	for _, fn := range options {
+		fn(pod)
+	}
+	return pod
+}
+
+func WithPodLabels(labels map[string]string) expectedPodOption {
+	return func(pod *corev1.Pod) {
+		pod.ObjectMeta.Labels = labels
+	}
+}
+
+func WithRunningContainer(name string) expectedPodOption {
+	return func(pod *corev1.Pod) {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		})
+	}
+}
+
+func WithTerminatedContainer(name string, exitCode int32, message string) expectedPodOption {
+	return func(pod *corev1.Pod) {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name: name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: exitCode,
+				Message:  message,
+			}},
+		})
+	}
+}
+
+func WithTerminatedInitContainer(name string, exitCode int32) expectedPodOption {
+	return func(pod *corev1.Pod) {
+		pod.Status.InitContainerStatuses = append(pod.Status.InitContainerStatuses, corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode}},
+		})
+	}
+}
+
+func WithWaitingContainer(name, reason string) expectedPodOption {
+	return func(pod *corev1.Pod) {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  name,
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason}},
+		})
+	}
+}
+
+func WithPodPhase(phase corev1.PodPhase) expectedPodOption {
+	return func(pod *corev1.Pod) {
+		pod.Status.Phase = phase
+	}
+}
@@ -22,6 +22,12 @@ func WithContainerOptions(options ...expectedContainerOption) ExpectedPodSpecOpt
 	}
 }
 
+func WithInitContainerOptions(options ...expectedContainerOption) ExpectedPodSpecOption {
+	return func(podSpec *corev1.PodSpec) {
+		podSpec.InitContainers = append(podSpec.InitContainers, ConstructExpectedContainerSpec(options...))
+	}
+}
+
 func WithVolumes(cms []string, secrets []string) ExpectedPodSpecOption {
 	return func(spec *corev1.PodSpec) {
 		spec.Volumes = append(spec.Volumes, ConstructVolumeSources(cms, secrets)...)
@@ -39,3 +45,15 @@ func WithRestartPolicy(policy string) ExpectedPodSpecOption {
 		spec.RestartPolicy = corev1.RestartPolicy(policy)
 	}
 }
+
+func WithNodeSelector(nodeSelector map[string]string) ExpectedPodSpecOption {
+	return func(spec *corev1.PodSpec) {
+		spec.NodeSelector = nodeSelector
+	}
+}
+
+func WithTolerations(tolerations []corev1.Toleration) ExpectedPodSpecOption {
+	return func(spec *corev1.PodSpec) {
+		spec.Tolerations = tolerations
+	}
+}
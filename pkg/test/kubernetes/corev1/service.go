@@ -0,0 +1,58 @@
+package corev1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type expectedServiceOption func(*corev1.Service)
+
+func ConstructExpectedService(options ...expectedServiceOption) corev1.Service {
+	service := corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Service",
+			APIVersion: "v1",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		}}
+
+	for _, fn := range options {
+		fn(&service)
+	}
+	return service
+}
+
+func WithServiceType(serviceType corev1.ServiceType) expectedServiceOption {
+	return func(service *corev1.Service) {
+		service.Spec.Type = serviceType
+	}
+}
+
+func WithClusterIP(clusterIP string) expectedServiceOption {
+	return func(service *corev1.Service) {
+		service.Spec.ClusterIP = clusterIP
+	}
+}
+
+func WithSelector(selector map[string]string) expectedServiceOption {
+	return func(service *corev1.Service) {
+		service.Spec.Selector = selector
+	}
+}
+
+func WithServicePort(name string, port int32) expectedServiceOption {
+	return WithServicePortTarget(name, port, name)
+}
+
+func WithServicePortTarget(name string, port int32, targetPort string) expectedServiceOption {
+	return func(service *corev1.Service) {
+		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+			Name:       name,
+			Protocol:   corev1.ProtocolTCP,
+			Port:       port,
+			TargetPort: intstr.FromString(targetPort),
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package transform
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//ApplyMeta merges the labels and annotations of desired on to existing and
+//replaces the owner references when desired has any, used by the Apply methods
+func ApplyMeta(existing *metav1.ObjectMeta, desired metav1.ObjectMeta) {
+	existing.Labels = GetStringMap(GetKVfromMap(desired.Labels), existing.Labels)
+	existing.Annotations = GetStringMap(GetKVfromMap(desired.Annotations), existing.Annotations)
+	if len(desired.OwnerReferences) > 0 {
+		existing.OwnerReferences = desired.OwnerReferences
+	}
+}